@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+
+	"fps/internal/game"
+	"fps/internal/input"
+	"fps/internal/level"
+)
+
+// runHeadless steps the simulation for a fixed number of ticks without
+// opening a window, then prints a summary of the resulting state
+func runHeadless(lvl *level.Level, startSlot int, ticks, fireEvery int, timeScale float32) {
+	gameState := game.New(lvl)
+	gameState.Loadout.Equip(startSlot)
+	gameState.Clock.SetTimeScale(timeScale)
+	source := headlessScript(fireEvery)
+
+	for tick := 0; tick < ticks; tick++ {
+		gameState.SavePreviousState()
+		gameState.Update(source.NextCommand(), game.TICK_DURATION)
+	}
+
+	p := gameState.Player
+	fmt.Printf("Level: %s\n", lvl.Name)
+	w := gameState.GetWeapon()
+	fmt.Printf("Weapon: %s ammo %d/%d\n", w.Name, w.Ammo, w.Reserve)
+	fmt.Printf("Ticks: %d at %d Hz (%.2fs game time)\n", gameState.Clock.Ticks, game.TICK_RATE, gameState.Clock.Now())
+	fmt.Printf("Player: position (%.2f, %.2f, %.2f) yaw %.2f pitch %.2f health %.0f deaths %d\n", p.Position.X, p.Position.Y, p.Position.Z, p.Yaw, p.Pitch, p.Health, p.Deaths)
+	for _, e := range gameState.Enemies.Enemies {
+		fmt.Printf("Enemy #%d: position (%.2f, %.2f, %.2f) health %.0f\n", e.ID, e.Position.X, e.Position.Y, e.Position.Z, e.Health)
+	}
+	fmt.Printf("Shots: %d fired, %d cube hits, %d enemy hits, %d headshots, %d penetrations, %d explosions, %d kills\n", gameState.Stats.ShotsFired, gameState.Stats.CubeHits, gameState.Stats.EnemyHits, gameState.Stats.Headshots, gameState.Stats.Penetrations, gameState.Stats.Explosions, gameState.Stats.Kills)
+}
+
+// headlessScript builds a looping script that fires once every fireEvery
+// ticks so shooting is exercised without a human at the keyboard
+func headlessScript(fireEvery int) input.InputSource {
+	if fireEvery <= 0 {
+		return input.NewScriptedSource(false)
+	}
+	return input.NewScriptedSource(true,
+		input.ScriptStep{Ticks: fireEvery - 1},
+		input.ScriptStep{Ticks: 1, Command: input.Command{Fire: true}},
+	)
+}
@@ -2,10 +2,16 @@
 package main
 
 import (
-	rl "github.com/gen2brain/raylib-go/raylib"
+	"flag"
+	"fmt"
+	"os"
+
 	"fps/internal/game"
 	"fps/internal/input"
+	"fps/internal/level"
 	"fps/internal/rendering"
+	"fps/internal/weapon"
+	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
 // Game constants
@@ -20,36 +26,99 @@ const (
 )
 
 func main() {
+	headless := flag.Bool("headless", false, "run the simulation without a window and print a summary")
+	ticks := flag.Int("ticks", 600, "number of simulation ticks to run in headless mode")
+	fireEvery := flag.Int("fire-every", 30, "fire a shot every N ticks in headless mode (0 disables)")
+	timeScale := flag.Float64("timescale", 1.0, "simulation speed multiplier in headless mode")
+	levelPath := flag.String("level", "", "path to a level JSON file (defaults to the built-in arena)")
+	dynamicCubes := flag.Bool("dynamic-cubes", false, "simulate every level box as a rigid body")
+	weaponName := flag.String("weapon", "rifle", "starting weapon: rifle, marksman, rocket or grenade")
+	flag.Parse()
+
+	lvl := level.Default()
+	if *levelPath != "" {
+		loaded, err := level.Load(*levelPath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		lvl = loaded
+	}
+
+	if *dynamicCubes {
+		for i := range lvl.Boxes {
+			lvl.Boxes[i].Dynamic = true
+		}
+	}
+
+	startSlot, ok := weapon.SlotByName(*weaponName)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown weapon %q\n", *weaponName)
+		os.Exit(1)
+	}
+
+	if *headless {
+		runHeadless(lvl, startSlot, *ticks, *fireEvery, float32(*timeScale))
+		return
+	}
+	runWindowed(lvl, startSlot)
+}
+
+// runWindowed opens a window and runs the interactive game loop
+func runWindowed(lvl *level.Level, startSlot int) {
 	// Set MSAA 4x hint for smoother anti-aliasing
 	// This will significantly reduce the stairstepping/aliasing on cube edges
 	// Also enable high DPI support for better rendering on high-resolution displays
 	rl.SetConfigFlags(FLAG_MSAA_4X_HINT | FLAG_WINDOW_HIGHDPI)
-	
+
 	// Initialize window
 	rl.InitWindow(WINDOW_WIDTH, WINDOW_HEIGHT, "FPS Camera with Perfect Mouse Control - Raylib")
 	defer rl.CloseWindow()
 
-	// Initialize game state
-	gameState := game.New()
+	// Initialize game state and presentation assets
+	gameState := game.New(lvl)
+	gameState.Loadout.Equip(startSlot)
+	assets := rendering.LoadAssets(gameState.Loadout.Slots)
+	defer assets.Unload()
 
 	// Enable cursor capture for FPS controls
 	rl.DisableCursor()
 	rl.SetTargetFPS(TARGET_FPS)
 
+	// Input is sampled every frame and consumed once per tick
+	source := input.NewRaylibSource()
+
+	// Time not yet consumed by fixed simulation ticks
+	accumulator := float32(0)
+
 	// Main game loop
 	for !rl.WindowShouldClose() {
-		deltaTime := rl.GetFrameTime()
+		frameTime := rl.GetFrameTime()
+		if frameTime > game.MAX_FRAME_TIME {
+			frameTime = game.MAX_FRAME_TIME
+		}
+		accumulator += frameTime
+		source.Sample()
 
-		// Handle all input
-		input.HandleMouseLook(gameState.Player, deltaTime)
-		input.HandleMovement(gameState.Player, deltaTime)
-		if input.HandleSystemInput() {
+		// Step the simulation in fixed increments independent of frame rate
+		quit := false
+		for accumulator >= game.TICK_DURATION {
+			cmd := source.NextCommand()
+			if input.HandleSystemInput(cmd) {
+				quit = true
+				break
+			}
+			gameState.SavePreviousState()
+			gameState.Update(cmd, game.TICK_DURATION)
+			accumulator -= game.TICK_DURATION
+		}
+		if quit {
 			break // Exit requested
 		}
 
-		// Update game systems
-		gameState.Update(deltaTime)
-		gameState.HandleShooting()
+		// Blend render state between the last two ticks
+		alpha := accumulator / game.TICK_DURATION
+		gameState.UpdateCamera(alpha)
 
 		// Render everything
 		rl.BeginDrawing()
@@ -58,21 +127,26 @@ func main() {
 		// Render the 3D world
 		rendering.RenderWorld(
 			gameState.Player,
-			gameState.Enemy,
-			gameState.GetCubes(),
+			gameState.Enemies.Enemies,
+			gameState.Level,
+			gameState.GetInterpolatedCubes(alpha),
+			gameState.GetCubeSizes(),
 			gameState.GetColors(),
 			gameState.GetHitTimers(),
-			gameState.TracerManager,
+			gameState.Effects,
+			gameState.Projectiles,
 			gameState.Camera,
+			alpha,
 		)
 
 		// Render weapon viewport
-		rendering.RenderWeaponViewport(gameState.WeaponCamera, gameState.WeaponModel)
+		aim := gameState.GetWeapon().GetAimBlend(alpha)
+		rendering.RenderWeaponViewport(assets.WeaponCamera, assets.WeaponModels[gameState.Loadout.Active], gameState.Loadout.Lowered(), aim)
 
 		// Render UI elements
-		rendering.RenderUI(gameState.Player, gameState.Enemy)
-		rendering.RenderCrosshair()
+		rendering.RenderUI(gameState.Player, gameState.Enemies.Enemies, gameState.GetWeapon(), gameState.Clock)
+		rendering.RenderCrosshair(aim)
 
 		rl.EndDrawing()
 	}
-} 
\ No newline at end of file
+}
@@ -0,0 +1,60 @@
+package clock
+
+// Constants for clock behavior
+const (
+	MAX_TIME_SCALE = 10.0
+)
+
+// Clock tracks simulated game time independently of wall time. Every system
+// reads time from the clock so pausing, slow motion and replays affect the
+// whole simulation consistently.
+type Clock struct {
+	Time      float64 // Scaled simulated seconds since start
+	Delta     float32 // Scaled duration of the current tick
+	Ticks     uint64  // Number of ticks stepped, including paused ones
+	TimeScale float32
+	Paused    bool
+}
+
+// New creates a clock running at normal speed
+func New() *Clock {
+	return &Clock{
+		TimeScale: 1.0,
+	}
+}
+
+// Advance steps the clock by one tick of the given unscaled duration
+func (c *Clock) Advance(deltaTime float32) {
+	c.Ticks++
+	if c.Paused {
+		c.Delta = 0
+		return
+	}
+	c.Delta = deltaTime * c.TimeScale
+	c.Time += float64(c.Delta)
+}
+
+// Now returns the simulated time in seconds
+func (c *Clock) Now() float32 {
+	return float32(c.Time)
+}
+
+// SetPaused pauses or resumes the clock
+func (c *Clock) SetPaused(paused bool) {
+	c.Paused = paused
+}
+
+// TogglePause flips the paused state
+func (c *Clock) TogglePause() {
+	c.Paused = !c.Paused
+}
+
+// SetTimeScale sets the simulation speed multiplier, clamped to a sane range
+func (c *Clock) SetTimeScale(scale float32) {
+	if scale < 0 {
+		scale = 0
+	} else if scale > MAX_TIME_SCALE {
+		scale = MAX_TIME_SCALE
+	}
+	c.TimeScale = scale
+}
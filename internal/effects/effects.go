@@ -0,0 +1,189 @@
+package effects
+
+import (
+	"math"
+	"math/rand"
+
+	"fps/internal/clock"
+	"fps/internal/physics"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Constants for the effects system
+const (
+	MAX_PARTICLES = 1024
+	EFFECTS_SEED  = 1 // Fixed so effects replay identically for the same inputs
+
+	BOUNCE_RESTITUTION = 0.3   // Fraction of speed into a surface kept when a particle bounces off it
+	BOUNCE_FRICTION    = 0.6   // Fraction of speed along a surface kept on each contact
+	BOUNCE_REST_SPEED  = 0.5   // Below this rebound speed a particle landing on a floor stays there
+	PARTICLE_SKIN      = 0.001 // Gap kept between a particle and the surface it touches
+)
+
+// Shape selects how a particle is drawn
+type Shape int
+
+const (
+	SHAPE_SPHERE Shape = iota // Ball of radius Size
+	SHAPE_CUBE                // Cube with sides of Size, used for debris and casings
+	SHAPE_LINE                // Segment from Position to End, used for tracers
+	SHAPE_RING                // Flat ring of radius Size on the ground plane, used for shockwaves
+)
+
+// Particle is a single simulated visual element
+type Particle struct {
+	Shape        Shape
+	Position     rl.Vector3
+	PrevPosition rl.Vector3
+	End          rl.Vector3 // Far end of SHAPE_LINE particles
+	Velocity     rl.Vector3
+	Gravity      float32 // Downward acceleration in units per second squared
+	Drag         float32 // Fraction of velocity lost per second
+	StartColor   rl.Color
+	EndColor     rl.Color
+	StartSize    float32
+	EndSize      float32
+	Lifetime     float32
+	Age          float32
+	PrevAge      float32
+	IsActive     bool
+}
+
+// System owns a fixed pool of particles. New particles always take the
+// slot after the last one written, so when the pool is full the oldest
+// particle is evicted first.
+type System struct {
+	Particles []Particle
+	next      int
+	rng       *rand.Rand
+}
+
+// NewSystem creates an effects system with a pool of MAX_PARTICLES
+func NewSystem() *System {
+	return &System{
+		Particles: make([]Particle, MAX_PARTICLES),
+		rng:       rand.New(rand.NewSource(EFFECTS_SEED)),
+	}
+}
+
+// Spawn adds a particle, evicting the oldest one if the pool is full
+func (s *System) Spawn(p Particle) {
+	p.PrevPosition = p.Position
+	p.Age = 0
+	p.PrevAge = 0
+	p.IsActive = true
+	s.Particles[s.next] = p
+	s.next = (s.next + 1) % len(s.Particles)
+}
+
+// Update ages and moves every active particle by the clock's tick duration.
+// Solid particles bounce off the world's geometry and settle on floors.
+func (s *System) Update(c *clock.Clock, world physics.Broadphase) {
+	deltaTime := c.Delta
+	for i := range s.Particles {
+		p := &s.Particles[i]
+		if !p.IsActive {
+			continue
+		}
+		p.PrevAge = p.Age
+		p.PrevPosition = p.Position
+		p.Age += deltaTime
+		if p.Age >= p.Lifetime {
+			p.IsActive = false
+			continue
+		}
+
+		p.Velocity.Y -= p.Gravity * deltaTime
+		if p.Drag > 0 {
+			p.Velocity = rl.Vector3Scale(p.Velocity, float32(math.Max(0, float64(1-p.Drag*deltaTime))))
+		}
+		step := rl.Vector3Scale(p.Velocity, deltaTime)
+		switch p.Shape {
+		case SHAPE_LINE:
+			p.Position = rl.Vector3Add(p.Position, step)
+			p.End = rl.Vector3Add(p.End, step)
+		case SHAPE_RING:
+			p.Position = rl.Vector3Add(p.Position, step)
+		default:
+			p.moveAndBounce(step, world)
+		}
+	}
+}
+
+// moveAndBounce moves a particle by step, stopping it at the first surface
+// in the way and bouncing it off with reduced speed
+func (p *Particle) moveAndBounce(step rl.Vector3, world physics.Broadphase) {
+	distance := rl.Vector3Length(step)
+	if distance == 0 {
+		return
+	}
+	direction := rl.Vector3Scale(step, 1/distance)
+	end := rl.Vector3Add(p.Position, step)
+
+	nearest := distance
+	var normal rl.Vector3
+	hit := false
+	region := rl.BoundingBox{Min: rl.Vector3Min(p.Position, end), Max: rl.Vector3Max(p.Position, end)}
+	for _, box := range world.QueryBox(region) {
+		if t, n, ok := physics.RayBox(p.Position, direction, distance, box); ok && t <= nearest {
+			nearest, normal, hit = t, n, true
+		}
+	}
+	if !hit {
+		p.Position = end
+		return
+	}
+
+	p.Position = rl.Vector3Add(p.Position, rl.Vector3Scale(direction, nearest))
+	p.Position = rl.Vector3Add(p.Position, rl.Vector3Scale(normal, PARTICLE_SKIN))
+
+	// Friction slows sliding; the speed into the surface rebounds until it is too small on a floor
+	into := rl.Vector3DotProduct(p.Velocity, normal)
+	tangent := rl.Vector3Subtract(p.Velocity, rl.Vector3Scale(normal, into))
+	rebound := -into * BOUNCE_RESTITUTION
+	if normal.Y > 0 && rebound < BOUNCE_REST_SPEED {
+		rebound = 0
+	}
+	p.Velocity = rl.Vector3Add(rl.Vector3Scale(tangent, BOUNCE_FRICTION), rl.Vector3Scale(normal, rebound))
+}
+
+// Active returns the number of live particles
+func (s *System) Active() int {
+	count := 0
+	for i := range s.Particles {
+		if s.Particles[i].IsActive {
+			count++
+		}
+	}
+	return count
+}
+
+// GetProgress returns how far through its life the particle is, from 0 to 1,
+// blended between the last two ticks
+func (p *Particle) GetProgress(alpha float32) float32 {
+	age := p.PrevAge + (p.Age-p.PrevAge)*alpha
+	return float32(math.Min(1, float64(age/p.Lifetime)))
+}
+
+// GetInterpolatedPosition blends the particle position between the last two ticks
+func (p *Particle) GetInterpolatedPosition(alpha float32) rl.Vector3 {
+	return rl.Vector3Lerp(p.PrevPosition, p.Position, alpha)
+}
+
+// GetColor returns the particle color at a point in its life
+func (p *Particle) GetColor(progress float32) rl.Color {
+	lerp := func(a, b uint8) uint8 {
+		return uint8(float32(a) + (float32(b)-float32(a))*progress)
+	}
+	return rl.Color{
+		R: lerp(p.StartColor.R, p.EndColor.R),
+		G: lerp(p.StartColor.G, p.EndColor.G),
+		B: lerp(p.StartColor.B, p.EndColor.B),
+		A: lerp(p.StartColor.A, p.EndColor.A),
+	}
+}
+
+// GetSize returns the particle size at a point in its life
+func (p *Particle) GetSize(progress float32) float32 {
+	return p.StartSize + (p.EndSize-p.StartSize)*progress
+}
@@ -0,0 +1,91 @@
+package effects
+
+import (
+	"math"
+	"testing"
+
+	"fps/internal/clock"
+	"fps/internal/physics"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// TestSpawnEvictsOldestFirst overfills the pool with impact effects and
+// checks the oldest particles are replaced in order rather than one slot
+// being overwritten again and again
+func TestSpawnEvictsOldestFirst(t *testing.T) {
+	s := NewSystem()
+	for i := 0; i < MAX_PARTICLES; i++ {
+		s.Spawn(Particle{Position: rl.Vector3{X: float32(i)}, Lifetime: 1})
+	}
+	for i := 0; i < 3; i++ {
+		s.ImpactSparks(rl.Vector3{Y: 1}, rl.Vector3{Y: 1})
+	}
+
+	if n := s.Active(); n != MAX_PARTICLES {
+		t.Fatalf("%d active particles, want a full pool of %d", n, MAX_PARTICLES)
+	}
+	evicted := 0
+	for i, p := range s.Particles {
+		if p.Position.Y == 0 && p.Position.X != float32(i) {
+			t.Fatalf("slot %d holds particle %v", i, p.Position.X)
+		}
+		if p.Position.Y != 0 {
+			evicted++
+		}
+	}
+	for i := 0; i < evicted; i++ {
+		if s.Particles[i].Position.Y == 0 {
+			t.Fatalf("particle %d survived while newer ones were evicted", i)
+		}
+	}
+	if evicted != 30 {
+		t.Fatalf("%d particles evicted by three impacts, want 30", evicted)
+	}
+}
+
+// TestParticlesCollideWithWorld drops casings onto the ground, onto a cube
+// and past the ground's edge, and checks each settles on the surface below
+// it or keeps falling where there is none
+func TestParticlesCollideWithWorld(t *testing.T) {
+	world := physics.BoxList{
+		{Min: rl.Vector3{X: -10, Y: -1, Z: -10}, Max: rl.Vector3{X: 10, Y: 0, Z: 10}}, // Ground slab
+		{Min: rl.Vector3{X: 2, Y: 0, Z: -0.5}, Max: rl.Vector3{X: 3, Y: 1, Z: 0.5}},   // Cube
+	}
+	cases := []struct {
+		name     string
+		start    rl.Vector3
+		velocity rl.Vector3
+		restY    float32 // Height the particle should settle at; NaN if it should fall
+	}{
+		{"ground", rl.Vector3{Y: 1}, rl.Vector3{X: 2}, 0},
+		{"cube top", rl.Vector3{X: 2.5, Y: 2}, rl.Vector3{Z: 0.5}, 1},
+		{"off the edge", rl.Vector3{X: 12, Y: 1}, rl.Vector3{}, float32(math.NaN())},
+	}
+
+	for _, tc := range cases {
+		s := NewSystem()
+		s.Spawn(Particle{Shape: SHAPE_CUBE, Position: tc.start, Velocity: tc.velocity, Gravity: 20, Lifetime: 5})
+		c := clock.New()
+		bounced := false
+		for tick := 0; tick < 120; tick++ {
+			c.Advance(1.0 / 60)
+			s.Update(c, world)
+			p := s.Particles[0]
+			bounced = bounced || (p.Velocity.Y > 0)
+		}
+
+		p := s.Particles[0]
+		if math.IsNaN(float64(tc.restY)) {
+			if p.Position.Y > -1 {
+				t.Fatalf("%s: particle stopped at y %v with no surface under it", tc.name, p.Position.Y)
+			}
+			continue
+		}
+		if !bounced {
+			t.Fatalf("%s: particle never bounced", tc.name)
+		}
+		if math.Abs(float64(p.Position.Y-tc.restY)) > 0.01 || p.Velocity.Y != 0 || rl.Vector3Length(p.Velocity) > 1e-3 {
+			t.Fatalf("%s: particle at y %v moving %+v, want at rest at y %v", tc.name, p.Position.Y, p.Velocity, tc.restY)
+		}
+	}
+}
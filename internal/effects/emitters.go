@@ -0,0 +1,259 @@
+package effects
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Effect durations shared with gameplay timing
+const (
+	TRACER_DURATION = 0.5
+	BLAST_DURATION  = 0.6
+)
+
+// Emitter describes a burst of similar particles
+type Emitter struct {
+	Shape          Shape
+	Count          int
+	Speed          float32
+	SpeedJitter    float32
+	Spread         float32 // Half-angle in radians of the cone particles leave in; Pi emits in all directions
+	PositionJitter float32
+	Lifetime       float32
+	LifetimeJitter float32
+	Gravity        float32
+	Drag           float32
+	StartColor     rl.Color
+	EndColor       rl.Color
+	StartSize      float32
+	EndSize        float32
+}
+
+// Emit spawns the emitter's burst at position, aimed along direction
+func (s *System) Emit(e Emitter, position, direction rl.Vector3) {
+	for i := 0; i < e.Count; i++ {
+		speed := e.Speed + e.SpeedJitter*s.signedRandom()
+		offset := rl.Vector3Scale(s.randomInSphere(), e.PositionJitter)
+		s.Spawn(Particle{
+			Shape:      e.Shape,
+			Position:   rl.Vector3Add(position, offset),
+			Velocity:   rl.Vector3Scale(s.randomInCone(direction, e.Spread), speed),
+			Gravity:    e.Gravity,
+			Drag:       e.Drag,
+			StartColor: e.StartColor,
+			EndColor:   e.EndColor,
+			StartSize:  e.StartSize,
+			EndSize:    e.EndSize,
+			Lifetime:   e.Lifetime + e.LifetimeJitter*s.signedRandom(),
+		})
+	}
+}
+
+// Tracer draws a fading line along a round's path
+func (s *System) Tracer(start, end rl.Vector3) {
+	s.Spawn(Particle{
+		Shape:      SHAPE_LINE,
+		Position:   start,
+		End:        end,
+		StartColor: rl.NewColor(255, 255, 0, 255),
+		EndColor:   rl.NewColor(255, 255, 0, 0),
+		Lifetime:   TRACER_DURATION,
+	})
+}
+
+// MuzzleFlash bursts a short-lived flash out of the barrel
+func (s *System) MuzzleFlash(position, direction rl.Vector3) {
+	s.Spawn(Particle{
+		Shape:      SHAPE_SPHERE,
+		Position:   position,
+		StartColor: rl.NewColor(255, 250, 200, 255),
+		EndColor:   rl.NewColor(255, 160, 0, 0),
+		StartSize:  0.12,
+		EndSize:    0.04,
+		Lifetime:   0.05,
+	})
+	s.Emit(Emitter{
+		Shape:      SHAPE_SPHERE,
+		Count:      6,
+		Speed:      3,
+		Spread:     0.3,
+		Lifetime:   0.06,
+		StartColor: rl.NewColor(255, 230, 120, 255),
+		EndColor:   rl.NewColor(255, 120, 0, 0),
+		StartSize:  0.05,
+		EndSize:    0.01,
+	}, position, direction)
+}
+
+// ShellCasing ejects a spent brass casing that tumbles to the ground
+func (s *System) ShellCasing(position, direction rl.Vector3) {
+	s.Emit(Emitter{
+		Shape:          SHAPE_CUBE,
+		Count:          1,
+		Speed:          2.5,
+		SpeedJitter:    0.5,
+		Spread:         0.3,
+		Lifetime:       1,
+		Gravity:        20,
+		StartColor:     rl.NewColor(200, 160, 50, 255),
+		EndColor:       rl.NewColor(200, 160, 50, 0),
+		StartSize:      0.03,
+		EndSize:        0.03,
+		LifetimeJitter: 0.2,
+	}, position, rl.Vector3Add(direction, rl.Vector3{Y: 0.5}))
+}
+
+// ImpactSparks throws sparks and a puff of dust off a struck surface
+func (s *System) ImpactSparks(point, normal rl.Vector3) {
+	s.Emit(Emitter{
+		Shape:          SHAPE_SPHERE,
+		Count:          8,
+		Speed:          4,
+		SpeedJitter:    1.5,
+		Spread:         0.8,
+		Lifetime:       0.3,
+		LifetimeJitter: 0.1,
+		Gravity:        15,
+		StartColor:     rl.NewColor(255, 200, 80, 255),
+		EndColor:       rl.NewColor(255, 60, 0, 0),
+		StartSize:      0.03,
+		EndSize:        0.01,
+	}, point, normal)
+	s.Emit(Emitter{
+		Shape:      SHAPE_SPHERE,
+		Count:      2,
+		Speed:      0.5,
+		Spread:     0.6,
+		Lifetime:   0.6,
+		Drag:       2,
+		StartColor: rl.NewColor(160, 150, 140, 180),
+		EndColor:   rl.NewColor(160, 150, 140, 0),
+		StartSize:  0.05,
+		EndSize:    0.2,
+	}, point, normal)
+}
+
+// Debris sprays splinters out of the far side of penetrated cover
+func (s *System) Debris(point, direction rl.Vector3) {
+	s.Emit(Emitter{
+		Shape:          SHAPE_CUBE,
+		Count:          6,
+		Speed:          3,
+		SpeedJitter:    1,
+		Spread:         0.6,
+		Lifetime:       0.6,
+		LifetimeJitter: 0.2,
+		Gravity:        15,
+		StartColor:     rl.NewColor(140, 110, 70, 255),
+		EndColor:       rl.NewColor(140, 110, 70, 0),
+		StartSize:      0.04,
+		EndSize:        0.02,
+	}, point, direction)
+}
+
+// BloodPuff sprays a red mist where an enemy was hit
+func (s *System) BloodPuff(point, normal rl.Vector3) {
+	s.Emit(Emitter{
+		Shape:          SHAPE_SPHERE,
+		Count:          10,
+		Speed:          2,
+		SpeedJitter:    1,
+		Spread:         0.9,
+		PositionJitter: 0.05,
+		Lifetime:       0.5,
+		LifetimeJitter: 0.15,
+		Gravity:        6,
+		Drag:           2,
+		StartColor:     rl.NewColor(180, 0, 0, 230),
+		EndColor:       rl.NewColor(90, 0, 0, 0),
+		StartSize:      0.05,
+		EndSize:        0.12,
+	}, point, normal)
+}
+
+// Smoke releases slowly rising, expanding smoke
+func (s *System) Smoke(position rl.Vector3, count int) {
+	s.Emit(Emitter{
+		Shape:          SHAPE_SPHERE,
+		Count:          count,
+		Speed:          0.4,
+		SpeedJitter:    0.2,
+		Spread:         math.Pi,
+		PositionJitter: 0.1,
+		Lifetime:       1.5,
+		LifetimeJitter: 0.4,
+		Gravity:        -0.8, // Buoyant
+		Drag:           1,
+		StartColor:     rl.NewColor(90, 90, 90, 150),
+		EndColor:       rl.NewColor(140, 140, 140, 0),
+		StartSize:      0.15,
+		EndSize:        0.6,
+	}, position, rl.Vector3{Y: 1})
+}
+
+// Blast plays an explosion: a swelling fireball, a ground shockwave, sparks and smoke
+func (s *System) Blast(center rl.Vector3, radius float32) {
+	s.Spawn(Particle{
+		Shape:      SHAPE_SPHERE,
+		Position:   center,
+		StartColor: rl.NewColor(255, 200, 60, 230),
+		EndColor:   rl.NewColor(255, 80, 0, 0),
+		StartSize:  radius * 0.1,
+		EndSize:    radius * 0.6,
+		Lifetime:   BLAST_DURATION,
+	})
+	s.Spawn(Particle{
+		Shape:      SHAPE_RING,
+		Position:   center,
+		StartColor: rl.NewColor(200, 200, 200, 200),
+		EndColor:   rl.NewColor(200, 200, 200, 0),
+		StartSize:  radius * 0.2,
+		EndSize:    radius,
+		Lifetime:   BLAST_DURATION,
+	})
+	s.Emit(Emitter{
+		Shape:          SHAPE_SPHERE,
+		Count:          20,
+		Speed:          8,
+		SpeedJitter:    3,
+		Spread:         math.Pi,
+		Lifetime:       0.5,
+		LifetimeJitter: 0.2,
+		Gravity:        15,
+		StartColor:     rl.NewColor(255, 220, 100, 255),
+		EndColor:       rl.NewColor(255, 60, 0, 0),
+		StartSize:      0.05,
+		EndSize:        0.02,
+	}, center, rl.Vector3{Y: 1})
+	s.Smoke(center, 6)
+}
+
+// signedRandom returns a uniform value in [-1, 1)
+func (s *System) signedRandom() float32 {
+	return s.rng.Float32()*2 - 1
+}
+
+// randomInSphere returns a uniform point inside the unit sphere
+func (s *System) randomInSphere() rl.Vector3 {
+	for {
+		v := rl.Vector3{X: s.signedRandom(), Y: s.signedRandom(), Z: s.signedRandom()}
+		if rl.Vector3LengthSqr(v) <= 1 {
+			return v
+		}
+	}
+}
+
+// randomInCone returns a unit vector within spread radians of direction
+func (s *System) randomInCone(direction rl.Vector3, spread float32) rl.Vector3 {
+	if spread >= math.Pi/2 || rl.Vector3LengthSqr(direction) == 0 {
+		// Wide cones are close enough to a sphere
+		v := s.randomInSphere()
+		if rl.Vector3LengthSqr(v) == 0 {
+			return rl.Vector3{Y: 1}
+		}
+		return rl.Vector3Normalize(v)
+	}
+	jitter := rl.Vector3Scale(s.randomInSphere(), float32(math.Tan(float64(spread))))
+	return rl.Vector3Normalize(rl.Vector3Add(rl.Vector3Normalize(direction), jitter))
+}
@@ -3,58 +3,104 @@ package enemy
 import (
 	"math"
 
+	"fps/internal/clock"
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
 // Constants for enemy behavior
 const (
-	ENEMY_HEIGHT = 2.0
-	ENEMY_RADIUS = 0.5
+	ENEMY_HEIGHT       = 2.0
+	ENEMY_RADIUS       = 0.5
+	MAX_HEALTH         = 100.0
 	HIT_FLASH_DURATION = 0.2
+	DEATH_DURATION     = 1.0 // Seconds the death effect plays before removal
+	PATROL_RADIUS      = 3.0
+	PATROL_SPEED       = 0.5
+	PHASE_STEP         = 2.0 // Seconds of patrol offset between consecutive IDs
 )
 
 // Enemy represents an enemy entity
 type Enemy struct {
-	Position    rl.Vector3
-	Health      float32
-	HitTimer    float32
-	Radius      float32
-	Height      float32
+	ID           int
+	Origin       rl.Vector3 // Center of the patrol circle
+	Position     rl.Vector3
+	PrevPosition rl.Vector3
+	Health       float32
+	HitTimer     float32
+	DeathTimer   float32
+	Phase        float32 // Offset into the movement pattern, in seconds
+	Radius       float32
+	Height       float32
+	Hitboxes     []Hitbox // Damageable shapes relative to Position
 }
 
-// New creates a new enemy with default values
-func New() *Enemy {
+// New creates a new enemy patrolling around the given spawn point
+func New(id int, spawn rl.Vector3) *Enemy {
 	return &Enemy{
-		Position: rl.Vector3{X: 8, Y: 1, Z: 8},
-		Health:   100.0,
-		HitTimer: 0.0,
-		Radius:   ENEMY_RADIUS,
-		Height:   ENEMY_HEIGHT,
+		ID:           id,
+		Origin:       spawn,
+		Position:     spawn,
+		PrevPosition: spawn,
+		Phase:        float32(id) * PHASE_STEP,
+		Health:       MAX_HEALTH,
+		HitTimer:     0.0,
+		Radius:       ENEMY_RADIUS,
+		Height:       ENEMY_HEIGHT,
+		Hitboxes:     DefaultHitboxes(),
 	}
 }
 
-// Update updates the enemy state
-func (e *Enemy) Update(deltaTime float32) {
+// Update updates the enemy state from the game clock
+func (e *Enemy) Update(c *clock.Clock) {
 	// Update hit timer
 	if e.HitTimer > 0 {
-		e.HitTimer -= deltaTime
+		e.HitTimer -= c.Delta
 	}
-	
-	// Simple circular movement pattern
-	time := float32(rl.GetTime())
-	radius := float32(3.0)
-	speed := float32(0.5)
-	
-	// Move in a circle around the center
-	e.Position.X = float32(math.Cos(float64(time*speed))) * radius
-	e.Position.Z = float32(math.Sin(float64(time*speed))) * radius
-	e.Position.Y = 1.0 // Keep enemy at ground level
+
+	// Dead enemies stay in place while the death effect plays out
+	if !e.IsAlive() {
+		if e.DeathTimer > 0 {
+			e.DeathTimer -= c.Delta
+		}
+		return
+	}
+
+	// Simple circular movement pattern driven by game time
+	time := c.Now() + e.Phase
+	radius := float32(PATROL_RADIUS)
+	speed := float32(PATROL_SPEED)
+
+	// Move in a circle around the spawn point
+	e.Position.X = e.Origin.X + float32(math.Cos(float64(time*speed)))*radius
+	e.Position.Z = e.Origin.Z + float32(math.Sin(float64(time*speed)))*radius
+	e.Position.Y = e.Origin.Y // Keep enemy at spawn height
 }
 
-// TakeDamage applies damage to the enemy and starts hit effect
-func (e *Enemy) TakeDamage(damage float32) {
+// SavePreviousState records the current position for render interpolation
+func (e *Enemy) SavePreviousState() {
+	e.PrevPosition = e.Position
+}
+
+// GetInterpolatedPosition blends the previous and current tick positions
+func (e *Enemy) GetInterpolatedPosition(alpha float32) rl.Vector3 {
+	return rl.Vector3Lerp(e.PrevPosition, e.Position, alpha)
+}
+
+// TakeDamage applies damage to the enemy and starts hit effect.
+// Returns true if this damage killed the enemy; dead enemies ignore damage.
+func (e *Enemy) TakeDamage(damage float32) bool {
+	if !e.IsAlive() {
+		return false
+	}
+
 	e.Health -= damage
 	e.HitTimer = HIT_FLASH_DURATION
+	if e.Health <= 0 {
+		e.Health = 0
+		e.DeathTimer = DEATH_DURATION
+		return true
+	}
+	return false
 }
 
 // IsAlive returns true if the enemy has health remaining
@@ -62,7 +108,20 @@ func (e *Enemy) IsAlive() bool {
 	return e.Health > 0
 }
 
-// GetBoundingBox returns the enemy's collision bounding box
+// IsRemovable returns true once the enemy is dead and its death effect has finished
+func (e *Enemy) IsRemovable() bool {
+	return !e.IsAlive() && e.DeathTimer <= 0
+}
+
+// GetDeathProgress returns how far the death effect has played, from 0 to 1
+func (e *Enemy) GetDeathProgress() float32 {
+	if e.IsAlive() {
+		return 0
+	}
+	return 1 - e.DeathTimer/DEATH_DURATION
+}
+
+// GetBoundingBox returns the enemy's collision bounding box, which encloses every hitbox
 func (e *Enemy) GetBoundingBox() rl.BoundingBox {
 	return rl.BoundingBox{
 		Min: rl.Vector3{
@@ -76,4 +135,4 @@ func (e *Enemy) GetBoundingBox() rl.BoundingBox {
 			Z: e.Position.Z + e.Radius,
 		},
 	}
-} 
\ No newline at end of file
+}
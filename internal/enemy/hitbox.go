@@ -0,0 +1,89 @@
+package enemy
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Damage multipliers per body region
+const (
+	HEAD_DAMAGE_MULTIPLIER  = 2.0
+	TORSO_DAMAGE_MULTIPLIER = 1.0
+	LEGS_DAMAGE_MULTIPLIER  = 0.75
+)
+
+// HitRegion identifies the body part a hitbox belongs to
+type HitRegion int
+
+const (
+	REGION_TORSO HitRegion = iota
+	REGION_HEAD
+	REGION_LEGS
+)
+
+// ShapeType selects how a hitbox's fields are interpreted
+type ShapeType int
+
+const (
+	SHAPE_SPHERE  ShapeType = iota // Centered at Start with Radius
+	SHAPE_CAPSULE                  // Segment from Start to End swept by Radius
+	SHAPE_BOX                      // Axis-aligned box with corners Start and End
+)
+
+// Hitbox is one damageable shape, positioned relative to the enemy's feet
+type Hitbox struct {
+	Region HitRegion
+	Shape  ShapeType
+	Start  rl.Vector3
+	End    rl.Vector3
+	Radius float32
+}
+
+// DefaultHitboxes returns the body layout for a standard enemy: capsule legs
+// and torso with a sphere head, filling the ENEMY_HEIGHT tall silhouette
+func DefaultHitboxes() []Hitbox {
+	return []Hitbox{
+		{Region: REGION_LEGS, Shape: SHAPE_CAPSULE, Start: rl.Vector3{Y: 0.3}, End: rl.Vector3{Y: 0.85}, Radius: 0.3},
+		{Region: REGION_TORSO, Shape: SHAPE_CAPSULE, Start: rl.Vector3{Y: 1.05}, End: rl.Vector3{Y: 1.3}, Radius: 0.4},
+		{Region: REGION_HEAD, Shape: SHAPE_SPHERE, Start: rl.Vector3{Y: 1.75}, Radius: 0.25},
+	}
+}
+
+// DamageMultiplier returns how much damage to this region is scaled by
+func (r HitRegion) DamageMultiplier() float32 {
+	switch r {
+	case REGION_HEAD:
+		return HEAD_DAMAGE_MULTIPLIER
+	case REGION_LEGS:
+		return LEGS_DAMAGE_MULTIPLIER
+	default:
+		return TORSO_DAMAGE_MULTIPLIER
+	}
+}
+
+// String returns the region name for logs and summaries
+func (r HitRegion) String() string {
+	switch r {
+	case REGION_HEAD:
+		return "head"
+	case REGION_LEGS:
+		return "legs"
+	default:
+		return "torso"
+	}
+}
+
+// Translated returns the hitbox moved from local space to the given position
+func (h Hitbox) Translated(position rl.Vector3) Hitbox {
+	h.Start = rl.Vector3Add(h.Start, position)
+	h.End = rl.Vector3Add(h.End, position)
+	return h
+}
+
+// GetWorldHitboxes returns the enemy's hitboxes at its current position
+func (e *Enemy) GetWorldHitboxes() []Hitbox {
+	hitboxes := make([]Hitbox, len(e.Hitboxes))
+	for i, h := range e.Hitboxes {
+		hitboxes[i] = h.Translated(e.Position)
+	}
+	return hitboxes
+}
@@ -0,0 +1,204 @@
+package enemy
+
+import (
+	"fps/internal/clock"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Constants for enemy lifecycle defaults
+const (
+	DEFAULT_RESPAWN_DELAY = 3.0
+)
+
+// RespawnLocation selects where a killed enemy comes back
+type RespawnLocation int
+
+const (
+	RESPAWN_AT_ORIGIN     RespawnLocation = iota // The point the enemy originally spawned at
+	RESPAWN_AT_NEXT_POINT                        // Cycle through the manager's spawn points
+)
+
+// Config controls how the manager handles dead enemies
+type Config struct {
+	RespawnEnabled  bool
+	RespawnDelay    float32 // Seconds after removal before the enemy returns
+	RespawnLocation RespawnLocation
+}
+
+// DefaultConfig returns a config that respawns enemies where they first appeared
+func DefaultConfig() Config {
+	return Config{
+		RespawnEnabled:  true,
+		RespawnDelay:    DEFAULT_RESPAWN_DELAY,
+		RespawnLocation: RESPAWN_AT_ORIGIN,
+	}
+}
+
+// EventType identifies a lifecycle event
+type EventType int
+
+const (
+	EVENT_SPAWN EventType = iota
+	EVENT_DEATH
+	EVENT_REMOVE
+)
+
+// Event records a lifecycle change for systems that react to enemies
+type Event struct {
+	Type     EventType
+	EnemyID  int
+	Position rl.Vector3
+}
+
+// pendingRespawn is an enemy waiting out its respawn delay
+type pendingRespawn struct {
+	Timer    float32
+	Position rl.Vector3
+}
+
+// Manager owns every enemy in the world and the points they spawn at
+type Manager struct {
+	Enemies     []*Enemy
+	SpawnPoints []rl.Vector3
+	Config      Config
+	Events      []Event
+	respawns    []pendingRespawn
+	nextID      int
+	nextPoint   int
+}
+
+// NewManager creates an empty manager with the given spawn points
+func NewManager(spawnPoints []rl.Vector3, config Config) *Manager {
+	return &Manager{
+		SpawnPoints: spawnPoints,
+		Config:      config,
+		nextID:      1,
+	}
+}
+
+// Spawn creates an enemy at an arbitrary position and returns it
+func (m *Manager) Spawn(position rl.Vector3) *Enemy {
+	e := New(m.nextID, position)
+	m.nextID++
+	m.Enemies = append(m.Enemies, e)
+	m.Events = append(m.Events, Event{Type: EVENT_SPAWN, EnemyID: e.ID, Position: position})
+	return e
+}
+
+// SpawnAtPoint creates an enemy at the spawn point with the given index
+func (m *Manager) SpawnAtPoint(index int) *Enemy {
+	if index < 0 || index >= len(m.SpawnPoints) {
+		return nil
+	}
+	return m.Spawn(m.SpawnPoints[index])
+}
+
+// SpawnAll creates one enemy at every spawn point
+func (m *Manager) SpawnAll() {
+	for i := range m.SpawnPoints {
+		m.SpawnAtPoint(i)
+	}
+}
+
+// Despawn removes the enemy with the given ID and reports whether it existed
+func (m *Manager) Despawn(id int) bool {
+	for i, e := range m.Enemies {
+		if e.ID == id {
+			m.Enemies = append(m.Enemies[:i], m.Enemies[i+1:]...)
+			m.Events = append(m.Events, Event{Type: EVENT_REMOVE, EnemyID: id, Position: e.Position})
+			return true
+		}
+	}
+	return false
+}
+
+// Get returns the enemy with the given ID, or nil if there is none
+func (m *Manager) Get(id int) *Enemy {
+	for _, e := range m.Enemies {
+		if e.ID == id {
+			return e
+		}
+	}
+	return nil
+}
+
+// Damage applies damage to an enemy and records a death event if it was killed
+func (m *Manager) Damage(e *Enemy, damage float32) bool {
+	killed := e.TakeDamage(damage)
+	if killed {
+		m.Events = append(m.Events, Event{Type: EVENT_DEATH, EnemyID: e.ID, Position: e.Position})
+	}
+	return killed
+}
+
+// Alive returns the enemies that are still alive
+func (m *Manager) Alive() []*Enemy {
+	alive := make([]*Enemy, 0, len(m.Enemies))
+	for _, e := range m.Enemies {
+		if e.IsAlive() {
+			alive = append(alive, e)
+		}
+	}
+	return alive
+}
+
+// Update advances every enemy by one tick, removes enemies whose death effect
+// has finished and respawns them once their delay has elapsed
+func (m *Manager) Update(c *clock.Clock) {
+	for _, e := range m.Enemies {
+		e.Update(c)
+	}
+
+	// Remove finished corpses and queue their respawn
+	remaining := m.Enemies[:0]
+	for _, e := range m.Enemies {
+		if !e.IsRemovable() {
+			remaining = append(remaining, e)
+			continue
+		}
+		m.Events = append(m.Events, Event{Type: EVENT_REMOVE, EnemyID: e.ID, Position: e.Position})
+		if m.Config.RespawnEnabled {
+			m.respawns = append(m.respawns, pendingRespawn{
+				Timer:    m.Config.RespawnDelay,
+				Position: m.respawnPosition(e),
+			})
+		}
+	}
+	m.Enemies = remaining
+
+	// Count down pending respawns
+	waiting := m.respawns[:0]
+	for _, r := range m.respawns {
+		r.Timer -= c.Delta
+		if r.Timer > 0 {
+			waiting = append(waiting, r)
+			continue
+		}
+		m.Spawn(r.Position)
+	}
+	m.respawns = waiting
+}
+
+// DrainEvents returns the events recorded since the last call and clears them
+func (m *Manager) DrainEvents() []Event {
+	events := m.Events
+	m.Events = nil
+	return events
+}
+
+// respawnPosition picks where a removed enemy should come back
+func (m *Manager) respawnPosition(e *Enemy) rl.Vector3 {
+	if m.Config.RespawnLocation == RESPAWN_AT_NEXT_POINT && len(m.SpawnPoints) > 0 {
+		position := m.SpawnPoints[m.nextPoint%len(m.SpawnPoints)]
+		m.nextPoint++
+		return position
+	}
+	return e.Origin
+}
+
+// SavePreviousState records every enemy's position for render interpolation
+func (m *Manager) SavePreviousState() {
+	for _, e := range m.Enemies {
+		e.SavePreviousState()
+	}
+}
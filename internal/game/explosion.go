@@ -0,0 +1,51 @@
+package game
+
+import (
+	"fps/internal/physics"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Explode applies an explosion's falloff damage and impulse to every enemy,
+// the player and every dynamic cube in range with a clear line of sight to
+// the center, and starts its blast effect
+func (g *GameState) Explode(ex physics.Explosion) {
+	g.Stats.Explosions++
+	g.Effects.Blast(ex.Center, ex.Radius)
+
+	for _, e := range g.Enemies.Enemies {
+		if !e.IsAlive() {
+			continue
+		}
+		box := e.GetBoundingBox()
+		if nearest, falloff := ex.Reach(box); falloff > 0 && g.blastReaches(ex, nearest, box, -1) {
+			g.Enemies.Damage(e, ex.Damage*falloff)
+		}
+	}
+
+	p := g.Player
+	box := p.GetCollisionBox()
+	if nearest, falloff := ex.Reach(box); falloff > 0 && g.blastReaches(ex, nearest, box, -1) {
+		p.TakeDamage(ex.Damage * falloff)
+		p.ApplyImpulse(ex.ImpulseAt(nearest, boxCenter(box)))
+	}
+
+	for _, b := range g.Bodies.Bodies {
+		box := b.GetBoundingBox()
+		if nearest, falloff := ex.Reach(box); falloff > 0 && g.blastReaches(ex, nearest, box, b.Index) {
+			b.ApplyImpulse(ex.ImpulseAt(nearest, b.Position))
+		}
+	}
+}
+
+// blastReaches reports whether the explosion can see the target, checking
+// the target's nearest point and its center so cover hiding part of a
+// target does not shield all of it
+func (g *GameState) blastReaches(ex physics.Explosion, nearest rl.Vector3, box rl.BoundingBox, ignoreCube int) bool {
+	return g.Scene.LineOfSight(ex.Center, nearest, ignoreCube) ||
+		g.Scene.LineOfSight(ex.Center, boxCenter(box), ignoreCube)
+}
+
+// boxCenter returns the center point of a box
+func boxCenter(box rl.BoundingBox) rl.Vector3 {
+	return rl.Vector3Scale(rl.Vector3Add(box.Min, box.Max), 0.5)
+}
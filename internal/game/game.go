@@ -1,91 +1,188 @@
 package game
 
 import (
-	rl "github.com/gen2brain/raylib-go/raylib"
-	"fps/internal/player"
+	"math"
+
+	"fps/internal/clock"
+	"fps/internal/effects"
 	"fps/internal/enemy"
+	"fps/internal/input"
+	"fps/internal/level"
 	"fps/internal/physics"
+	"fps/internal/player"
+	"fps/internal/weapon"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Simulation timing constants
+const (
+	TICK_RATE      = 60              // Simulation ticks per second
+	TICK_DURATION  = 1.0 / TICK_RATE // Fixed timestep in seconds
+	MAX_FRAME_TIME = 0.25            // Clamp on frame time to avoid a spiral of death
+)
+
+// Camera constants
+const (
+	CAMERA_FOV = 60.0 // Vertical field of view in degrees when not aiming
+)
+
+// World constants
+const (
+	GROUND_THICKNESS     = 1.0  // Depth of the collision slab under the ground plane
+	BLAST_SURFACE_OFFSET = 0.05 // Distance explosions are lifted off the surface they hit
+)
+
+// Muzzle placement relative to the eye, for effects that leave the gun
+const (
+	MUZZLE_OFFSET_RIGHT   = 0.2
+	MUZZLE_OFFSET_DOWN    = 0.15
+	MUZZLE_OFFSET_FORWARD = 0.6
 )
 
 // GameState holds all the game state
 type GameState struct {
-	Player         *player.Player
-	Camera         rl.Camera3D
-	WeaponCamera   rl.Camera3D
-	WeaponModel    rl.Model
-	Cubes          []rl.Vector3
-	Colors         []rl.Color
-	OriginalColors []rl.Color
-	HitTimers      []float32
-	TracerManager  *physics.TracerManager
-	Enemy          *enemy.Enemy
-}
-
-// New creates a new game state
-func New() *GameState {
-	cubes := []rl.Vector3{
-		{X: 5, Y: 0.5, Z: 5},
-		{X: -5, Y: 0.5, Z: 5},
-		{X: 5, Y: 0.5, Z: -5},
-		{X: -5, Y: 0.5, Z: -5},
-		{X: 0, Y: 0.5, Z: 10},
-	}
-
-	originalColors := []rl.Color{rl.Red, rl.Blue, rl.Yellow, rl.Purple, rl.Orange}
-	colors := make([]rl.Color, len(originalColors))
-	copy(colors, originalColors)
+	Player          *player.Player
+	Clock           *clock.Clock
+	Camera          rl.Camera3D
+	Level           *level.Level
+	Cubes           []rl.Vector3
+	CubeSizes       []rl.Vector3
+	Colors          []rl.Color
+	OriginalColors  []rl.Color
+	HitTimers       []float32
+	CubeResistances []float32
+	CubeBodies      []*physics.RigidBody // Rigid body driving each dynamic cube, nil for fixed ones
+	Bodies          *physics.BodyManager
+	Effects         *effects.System
+	Projectiles     *physics.ProjectileManager
+	Scene           *physics.Scene
+	Enemies         *enemy.Manager
+	Loadout         *weapon.Loadout
+	Stats           Stats
+}
 
-	// Load weapon model
-	weaponModel := rl.LoadModel("assets/ak47.glb")
+// Stats tracks gameplay counters for summaries and debugging
+type Stats struct {
+	ShotsFired   int
+	CubeHits     int
+	EnemyHits    int
+	Headshots    int
+	Penetrations int
+	Explosions   int
+	Kills        int
+}
 
-	// Check if model loaded successfully
-	if weaponModel.MeshCount == 0 {
-		// Model failed to load - create a simple fallback cube
-		weaponModel = rl.LoadModelFromMesh(rl.GenMeshCube(1.0, 1.0, 3.0))
+// New creates a new game state from a level layout
+func New(lvl *level.Level) *GameState {
+	cubes := make([]rl.Vector3, len(lvl.Boxes))
+	cubeSizes := make([]rl.Vector3, len(lvl.Boxes))
+	originalColors := make([]rl.Color, len(lvl.Boxes))
+	resistances := make([]float32, len(lvl.Boxes))
+	cubeBodies := make([]*physics.RigidBody, len(lvl.Boxes))
+	bodies := physics.NewBodyManager()
+	for i, box := range lvl.Boxes {
+		cubes[i] = box.Position
+		cubeSizes[i] = box.Size
+		originalColors[i] = lvl.BoxColor(box)
+		resistances[i] = lvl.BoxResistance(box)
+		if box.Dynamic {
+			cubeBodies[i] = physics.NewRigidBody(i, box.Position, box.Size, lvl.BoxMass(box))
+			bodies.Add(cubeBodies[i])
+		}
 	}
+	colors := make([]rl.Color, len(originalColors))
+	copy(colors, originalColors)
+
+	enemies := enemy.NewManager(lvl.EnemySpawns, enemyConfig(lvl.EnemyRespawn))
+	enemies.SpawnAll()
+	enemies.DrainEvents()
 
-	return &GameState{
-		Player:         player.New(),
-		Camera:         rl.Camera3D{
+	g := &GameState{
+		Player: player.New(lvl.PlayerSpawn.Position, lvl.PlayerSpawn.Yaw),
+		Clock:  clock.New(),
+		Camera: rl.Camera3D{
 			Position:   rl.Vector3{X: 0, Y: 2, Z: 10},
 			Target:     rl.Vector3{X: 0, Y: 2, Z: 0},
 			Up:         rl.Vector3{X: 0, Y: 1, Z: 0},
-			Fovy:       60,
-			Projection: rl.CameraPerspective,
-		},
-		WeaponCamera:   rl.Camera3D{
-			Position:   rl.Vector3{X: 0, Y: 0, Z: 5},
-			Target:     rl.Vector3{X: 0, Y: 0, Z: 0},
-			Up:         rl.Vector3{X: 0, Y: 1, Z: 0},
-			Fovy:       60,
+			Fovy:       CAMERA_FOV,
 			Projection: rl.CameraPerspective,
 		},
-		WeaponModel:    weaponModel,
-		Cubes:          cubes,
-		Colors:         colors,
-		OriginalColors: originalColors,
-		HitTimers:      make([]float32, len(cubes)),
-		TracerManager:  physics.NewTracerManager(),
-		Enemy:          enemy.New(),
+		Level:           lvl,
+		Cubes:           cubes,
+		CubeSizes:       cubeSizes,
+		Colors:          colors,
+		OriginalColors:  originalColors,
+		HitTimers:       make([]float32, len(cubes)),
+		CubeResistances: resistances,
+		CubeBodies:      cubeBodies,
+		Bodies:          bodies,
+		Effects:         effects.NewSystem(),
+		Projectiles:     physics.NewProjectileManager(),
+		Enemies:         enemies,
+		Loadout:         weapon.DefaultLoadout(),
 	}
+	g.Scene = g.buildScene()
+	return g
 }
 
-// UpdateCamera updates the camera position and target based on player state
-func (g *GameState) UpdateCamera() {
-	// Update camera position to player eye position
-	g.Camera.Position = g.Player.GetEyePosition()
+// enemyConfig returns the enemy manager settings for a level's respawn
+// rules, keeping the defaults for anything the level leaves unset
+func enemyConfig(respawn level.EnemyRespawn) enemy.Config {
+	config := enemy.DefaultConfig()
+	if respawn.Enabled != nil {
+		config.RespawnEnabled = *respawn.Enabled
+	}
+	if respawn.Delay > 0 {
+		config.RespawnDelay = respawn.Delay
+	}
+	if respawn.Location == level.ENEMY_RESPAWN_NEXT {
+		config.RespawnLocation = enemy.RESPAWN_AT_NEXT_POINT
+	}
+	return config
+}
+
+// buildScene registers the level's fixed geometry with a new scene;
+// dynamic cubes and enemies are tracked as moving entities
+func (g *GameState) buildScene() *physics.Scene {
+	scene := physics.NewScene()
+	scene.AddStatic(g.GetFloorBounds(), physics.HIT_STATIC, 0)
+	for i, box := range g.GetCubeBounds() {
+		if g.CubeBodies[i] == nil {
+			scene.AddStatic(box, physics.HIT_CUBE, i)
+		}
+	}
+	scene.SyncEnemies(g.Enemies.Enemies)
+	scene.SyncBodies(g.Bodies.Bodies)
+	return scene
+}
+
+// SavePreviousState snapshots entity state at the start of a tick for interpolation
+func (g *GameState) SavePreviousState() {
+	g.Player.SavePreviousState()
+	g.Enemies.SavePreviousState()
+	g.Bodies.SavePreviousState()
+}
 
-	// Update camera target based on yaw and pitch
+// UpdateCamera updates the camera position and target based on player state,
+// blending the eye position between the last two ticks by alpha
+func (g *GameState) UpdateCamera(alpha float32) {
+	// Update camera position to interpolated player eye position
+	g.Camera.Position = g.Player.GetInterpolatedEyePosition(alpha)
+
+	// Update camera target based on interpolated yaw and pitch
 	targetDistance := float32(1.0)
-	forward := g.Player.GetForwardVector()
+	forward := g.Player.GetInterpolatedForwardVector(alpha)
 	g.Camera.Target = rl.Vector3Add(g.Camera.Position, rl.Vector3Scale(forward, targetDistance))
+
+	// Narrow the view as the sights come up
+	g.Camera.Fovy = g.GetWeapon().GetFov(CAMERA_FOV, alpha)
 }
 
 // UpdateHitTimers decrements hit timers and resets colors when they expire
-func (g *GameState) UpdateHitTimers(deltaTime float32) {
+func (g *GameState) UpdateHitTimers(c *clock.Clock) {
 	for i := range g.HitTimers {
 		if g.HitTimers[i] > 0 {
-			g.HitTimers[i] -= deltaTime
+			g.HitTimers[i] -= c.Delta
 			if g.HitTimers[i] <= 0 {
 				// Reset to original color when timer expires
 				g.Colors[i] = g.OriginalColors[i]
@@ -94,52 +191,275 @@ func (g *GameState) UpdateHitTimers(deltaTime float32) {
 	}
 }
 
-// Update updates all game systems
-func (g *GameState) Update(deltaTime float32) {
-	g.UpdateCamera()
-	g.UpdateHitTimers(deltaTime)
-	g.TracerManager.Update(deltaTime)
-	g.Enemy.Update(deltaTime)
+// Update applies one tick's input command and advances all game systems.
+// deltaTime is the unscaled tick length; systems read scaled time from the clock.
+func (g *GameState) Update(cmd input.Command, deltaTime float32) {
+	if cmd.TogglePause {
+		g.Clock.TogglePause()
+	}
+	g.Clock.Advance(deltaTime)
+	if g.Clock.Paused {
+		return
+	}
+
+	// Zooming in slows the view down to match
+	input.HandleMouseLook(g.Player, cmd, g.GetWeapon().GetFov(CAMERA_FOV, 1)/CAMERA_FOV)
+	input.HandleMovement(g.Player, cmd, g.Scene, g.Clock.Delta)
+
+	g.UpdateHitTimers(g.Clock)
+	g.Effects.Update(g.Clock, g.Scene)
+	g.Enemies.Update(g.Clock)
+	g.UpdateBodies()
+	g.ApplyWorldRules()
+	g.Scene.SyncEnemies(g.Enemies.Enemies)
+	g.Scene.SyncBodies(g.Bodies.Bodies)
+	g.UpdateProjectiles()
+	g.UpdateWeapon(cmd)
+
+	g.HandleEnemyEvents()
+}
+
+// UpdateWeapon handles weapon switching, advances the weapon in hand and
+// handles aiming, the trigger and reload key
+func (g *GameState) UpdateWeapon(cmd input.Command) {
+	l := g.Loadout
+	if cmd.SelectSlot > 0 {
+		l.Select(cmd.SelectSlot - 1)
+	} else if cmd.CycleWeapon != 0 {
+		l.Cycle(cmd.CycleWeapon)
+	}
+	// Settle recoil before the cooldown ticks down, so only idle ticks recover
+	g.Player.Rotate(l.Current().RecoverAim(g.Clock.Delta))
+	l.Update(g.Clock)
+
+	// Sights come down while switching, sprinting or reloading
+	w := l.Current()
+	w.UpdateAim(cmd.Aim && l.IsReady() && !g.Player.Sprinting && !w.IsReloading(), g.Clock.Delta)
+	if !l.IsReady() {
+		return
+	}
+
+	if cmd.ToggleFireMode {
+		w.CycleFireMode()
+	}
+	if cmd.Reload {
+		w.StartReload()
+	}
+
+	// Sprinting lowers the weapon
+	rounds := w.Trigger(cmd.Fire && !g.Player.Sprinting)
+	for i := 0; i < rounds; i++ {
+		g.Fire()
+	}
+}
+
+// HandleEnemyEvents reacts to enemy lifecycle events recorded this tick
+func (g *GameState) HandleEnemyEvents() {
+	for _, event := range g.Enemies.DrainEvents() {
+		if event.Type == enemy.EVENT_DEATH {
+			g.Stats.Kills++
+		}
+	}
+}
+
+// Fire shoots one round of the current weapon from the player's eye,
+// either resolving it instantly or launching a projectile, then kicks the
+// view with recoil. Ammunition and fire rate are checked by the caller.
+func (g *GameState) Fire() {
+	g.Stats.ShotsFired++
+	w := g.GetWeapon()
+
+	// Shots leave the simulated eye position, scattered within the weapon's
+	// spread around the direction the player is looking
+	origin := g.Player.GetEyePosition()
+	direction := g.shotDirection(w.Deviation(w.Spread(g.Player.GetMovementFactor())))
+
+	// The round's direction is set, so recoil only moves the view for the next one
+	w.AddRecoil(g.Player.Rotate(w.Kick()))
+
+	muzzle := g.muzzlePosition()
+	g.Effects.MuzzleFlash(muzzle, direction)
+	g.Effects.ShellCasing(muzzle, g.Player.GetRightVector())
+
+	if w.Ballistics == weapon.BALLISTICS_PROJECTILE {
+		g.Projectiles.Spawn(physics.Projectile{
+			Position:    origin,
+			Velocity:    rl.Vector3Scale(direction, w.MuzzleVelocity),
+			Gravity:     w.Gravity,
+			Damage:      w.Damage,
+			Impulse:     w.Impulse,
+			BlastRadius: w.BlastRadius,
+			Range:       w.Range,
+		})
+		return
+	}
+	g.fireHitscan(origin, direction)
 }
 
-// HandleShooting processes shooting input and raycast collision
-func (g *GameState) HandleShooting() {
-	if rl.IsMouseButtonPressed(rl.MouseLeftButton) && rl.IsCursorHidden() {
-		// Create ray from camera position in the direction camera is looking
-		rayOrigin := g.Camera.Position
-		rayDirection := rl.Vector3Normalize(rl.Vector3Subtract(g.Camera.Target, g.Camera.Position))
+// shotDirection returns the view direction turned by yaw and pitch offsets in radians
+func (g *GameState) shotDirection(yaw, pitch float32) rl.Vector3 {
+	forward := rl.Vector3Normalize(g.Player.GetForwardVector())
+	right := g.Player.GetRightVector()
+	up := rl.Vector3CrossProduct(right, forward)
+	direction := rl.Vector3Add(forward, rl.Vector3Scale(right, float32(math.Tan(float64(yaw)))))
+	direction = rl.Vector3Add(direction, rl.Vector3Scale(up, float32(math.Tan(float64(pitch)))))
+	return rl.Vector3Normalize(direction)
+}
+
+// fireHitscan resolves a shot instantly against the scene, passing through
+// thin cover while the weapon's penetration power lasts
+func (g *GameState) fireHitscan(rayOrigin, rayDirection rl.Vector3) {
+	w := g.GetWeapon()
+
+	// Default end point for tracer (if nothing stops the round, draw a long line)
+	tracerEnd := rl.Vector3Add(rayOrigin, rl.Vector3Scale(rayDirection, w.Range))
 
-		// Default end point for tracer (if no hit, draw a long line)
-		maxRange := float32(100.0)
-		tracerEnd := rl.Vector3Add(rayOrigin, rl.Vector3Scale(rayDirection, maxRange))
+	damage := w.Damage
+	power := w.Penetration
+	for _, hit := range g.Scene.Raycast(rayOrigin, rayDirection, w.Range) {
+		g.applyHit(hit, damage, rl.Vector3Scale(rayDirection, w.Impulse))
 
-		// Check collision with cubes
-		hit, hitPoint, cubeIndex := physics.CheckCubeCollision(rayOrigin, rayDirection, g.Cubes, g.Colors, g.HitTimers)
-		if hit {
-			// Hit detected! Flash white and start timer
-			g.Colors[cubeIndex] = rl.White
-			g.HitTimers[cubeIndex] = physics.HIT_FLASH_DURATION
-			tracerEnd = hitPoint
+		// Spend penetration power on the material's thickness along the ray
+		cost := g.hitResistance(hit) * hit.Thickness()
+		if cost <= 0 || cost > power {
+			tracerEnd = hit.Point
+			break
 		}
+		power -= cost
+		damage = w.PenetrationDamage(power)
+		g.Effects.Debris(hit.ExitPoint(rayOrigin, rayDirection), rayDirection)
+		g.Stats.Penetrations++
+	}
+
+	// Draw the tracer from the gun to where the round stopped
+	g.Effects.Tracer(g.muzzlePosition(), tracerEnd)
+}
 
-		// Check collision with enemy
-		enemyHit, enemyHitPoint := physics.CheckEnemyCollision(rayOrigin, rayDirection, g.Enemy)
-		if enemyHit {
-			// Enemy hit! Apply damage and flash effect
-			g.Enemy.TakeDamage(25.0)
-			tracerEnd = enemyHitPoint
+// muzzlePosition approximates where the gun barrel ends in the world
+func (g *GameState) muzzlePosition() rl.Vector3 {
+	p := g.Player
+	muzzle := p.GetEyePosition()
+	muzzle = rl.Vector3Add(muzzle, rl.Vector3Scale(p.GetRightVector(), MUZZLE_OFFSET_RIGHT))
+	muzzle = rl.Vector3Add(muzzle, rl.Vector3Scale(p.GetUpVector(), -MUZZLE_OFFSET_DOWN))
+	return rl.Vector3Add(muzzle, rl.Vector3Scale(p.GetForwardVector(), MUZZLE_OFFSET_FORWARD))
+}
+
+// UpdateBodies steps the rigid body simulation against fixed geometry and
+// the player, and moves the dynamic cubes to match. The player shoves
+// bodies they walk into and holds up bodies that land on them.
+func (g *GameState) UpdateBodies() {
+	pusher := physics.Pusher{Box: g.Player.GetCollisionBox(), Velocity: g.Player.PushVelocity}
+	g.Bodies.Update(g.Clock, g.Scene.Static, pusher)
+	for _, b := range g.Bodies.Bodies {
+		g.Cubes[b.Index] = b.Position
+	}
+}
+
+// UpdateProjectiles steps rounds in flight and applies their hits
+func (g *GameState) UpdateProjectiles() {
+	// Explosive rounds leave a smoke trail
+	for _, p := range g.Projectiles.Projectiles {
+		if p.BlastRadius > 0 {
+			g.Effects.Smoke(p.Position, 1)
 		}
+	}
 
-		// Create tracer from camera to hit point
-		g.TracerManager.AddTracer(rayOrigin, tracerEnd)
+	for _, ph := range g.Projectiles.Update(g.Clock, g.Scene, g.Level.InBounds) {
+		p := ph.Projectile
+		if p.BlastRadius > 0 {
+			// Lift the blast off the surface so the struck face does not occlude it
+			center := rl.Vector3Add(ph.Hit.Point, rl.Vector3Scale(ph.Hit.Normal, BLAST_SURFACE_OFFSET))
+			g.Explode(physics.Explosion{Center: center, Radius: p.BlastRadius, Damage: p.Damage, Impulse: p.Impulse})
+			continue
+		}
+		push := rl.Vector3Scale(rl.Vector3Normalize(p.Velocity), p.Impulse)
+		g.applyHit(ph.Hit, p.Damage, push)
+	}
+}
+
+// applyHit marks the impact of a round and applies its effect on what it
+// struck; impulse pushes dynamic cubes
+func (g *GameState) applyHit(hit physics.Hit, damage float32, impulse rl.Vector3) {
+	switch hit.Kind {
+	case physics.HIT_STATIC:
+		g.Effects.ImpactSparks(hit.Point, hit.Normal)
+	case physics.HIT_CUBE:
+		g.Effects.ImpactSparks(hit.Point, hit.Normal)
+		// Flash white and start timer
+		g.Colors[hit.Index] = rl.White
+		g.HitTimers[hit.Index] = physics.HIT_FLASH_DURATION
+		g.Stats.CubeHits++
+		if body := g.CubeBodies[hit.Index]; body != nil {
+			body.ApplyImpulse(impulse)
+		}
+	case physics.HIT_ENEMY:
+		// Apply damage scaled by the body part struck
+		g.Effects.BloodPuff(hit.Point, hit.Normal)
+		g.Enemies.Damage(hit.Enemy, damage*hit.Region.DamageMultiplier())
+		g.Stats.EnemyHits++
+		if hit.Headshot {
+			g.Stats.Headshots++
+		}
 	}
 }
 
+// hitResistance returns the penetration resistance of what a ray hit.
+// Only cubes with a penetrable material let rounds through.
+func (g *GameState) hitResistance(hit physics.Hit) float32 {
+	if hit.Kind != physics.HIT_CUBE {
+		return 0
+	}
+	return g.CubeResistances[hit.Index]
+}
+
+// GetWeapon returns the weapon in the player's hands
+func (g *GameState) GetWeapon() *weapon.Weapon {
+	return g.Loadout.Current()
+}
+
 // GetCubes returns the cubes slice
 func (g *GameState) GetCubes() []rl.Vector3 {
 	return g.Cubes
 }
 
+// GetInterpolatedCubes returns cube positions blended between the last two
+// ticks, so dynamic cubes move smoothly between simulation steps
+func (g *GameState) GetInterpolatedCubes(alpha float32) []rl.Vector3 {
+	cubes := make([]rl.Vector3, len(g.Cubes))
+	copy(cubes, g.Cubes)
+	for _, b := range g.Bodies.Bodies {
+		cubes[b.Index] = b.GetInterpolatedPosition(alpha)
+	}
+	return cubes
+}
+
+// GetCubeSizes returns the cube sizes slice
+func (g *GameState) GetCubeSizes() []rl.Vector3 {
+	return g.CubeSizes
+}
+
+// GetCubeBounds returns the collision box of every cube
+func (g *GameState) GetCubeBounds() []rl.BoundingBox {
+	bounds := make([]rl.BoundingBox, len(g.Cubes))
+	for i, pos := range g.Cubes {
+		half := rl.Vector3Scale(g.CubeSizes[i], 0.5)
+		bounds[i] = rl.BoundingBox{
+			Min: rl.Vector3Subtract(pos, half),
+			Max: rl.Vector3Add(pos, half),
+		}
+	}
+	return bounds
+}
+
+// GetFloorBounds returns the collision slab under the ground plane
+func (g *GameState) GetFloorBounds() rl.BoundingBox {
+	ground := g.Level.Ground
+	return rl.BoundingBox{
+		Min: rl.Vector3{X: -ground.Width / 2, Y: -GROUND_THICKNESS, Z: -ground.Depth / 2},
+		Max: rl.Vector3{X: ground.Width / 2, Y: 0, Z: ground.Depth / 2},
+	}
+}
+
 // GetColors returns the colors slice
 func (g *GameState) GetColors() []rl.Color {
 	return g.Colors
@@ -148,4 +468,4 @@ func (g *GameState) GetColors() []rl.Color {
 // GetHitTimers returns the hit timers slice
 func (g *GameState) GetHitTimers() []float32 {
 	return g.HitTimers
-} 
\ No newline at end of file
+}
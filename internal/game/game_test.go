@@ -0,0 +1,372 @@
+package game
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"fps/internal/enemy"
+	"fps/internal/input"
+	"fps/internal/level"
+	"fps/internal/player"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// shootingRange returns a level with no enemies and the player facing a
+// wall close enough that every round hits it
+func shootingRange() *level.Level {
+	lvl := level.Default()
+	lvl.EnemySpawns = nil
+	lvl.PlayerSpawn = level.Spawn{Position: rl.Vector3{Z: 5}, Yaw: math.Pi}
+	lvl.Boxes = []level.Box{
+		{Position: rl.Vector3{Y: 1.5}, Size: rl.Vector3{X: 4, Y: 3, Z: 1}},
+	}
+	return lvl
+}
+
+// runScript steps a new game on the level for the given number of ticks,
+// taking commands from source
+func runScript(lvl *level.Level, source input.InputSource, ticks int) *GameState {
+	g := New(lvl)
+	for tick := 0; tick < ticks; tick++ {
+		g.SavePreviousState()
+		g.Update(source.NextCommand(), TICK_DURATION)
+	}
+	return g
+}
+
+// snapshot collects the simulation state that replays must reproduce
+type snapshot struct {
+	Stats       Stats
+	Ticks       uint64
+	Position    rl.Vector3
+	Velocity    rl.Vector3
+	Yaw, Pitch  float32
+	Health      float32
+	Enemies     []rl.Vector3
+	EnemyHealth []float32
+	Cubes       []rl.Vector3
+	Ammo        []int
+	Particles   int
+	Projectiles int
+}
+
+// takeSnapshot records the state of a game for comparison
+func takeSnapshot(g *GameState) snapshot {
+	s := snapshot{
+		Stats:       g.Stats,
+		Ticks:       g.Clock.Ticks,
+		Position:    g.Player.Position,
+		Velocity:    g.Player.Velocity,
+		Yaw:         g.Player.Yaw,
+		Pitch:       g.Player.Pitch,
+		Health:      g.Player.Health,
+		Cubes:       append([]rl.Vector3(nil), g.Cubes...),
+		Particles:   g.Effects.Active(),
+		Projectiles: len(g.Projectiles.Projectiles),
+	}
+	for _, e := range g.Enemies.Enemies {
+		s.Enemies = append(s.Enemies, e.Position)
+		s.EnemyHealth = append(s.EnemyHealth, e.Health)
+	}
+	for _, w := range g.Loadout.Slots {
+		s.Ammo = append(s.Ammo, w.Ammo, w.Reserve)
+	}
+	return s
+}
+
+// TestScriptedShotsHitWall fires single shots at a wall and checks every
+// round is counted and lands
+func TestScriptedShotsHitWall(t *testing.T) {
+	source := input.NewScriptedSource(true,
+		input.ScriptStep{Ticks: 1, Command: input.Command{Fire: true}},
+		input.ScriptStep{Ticks: 9},
+	)
+	g := runScript(shootingRange(), source, 120)
+
+	want := Stats{ShotsFired: 12, CubeHits: 12}
+	if g.Stats != want {
+		t.Fatalf("stats = %+v, want %+v", g.Stats, want)
+	}
+	if w := g.GetWeapon(); w.Ammo != w.MagazineSize-12 {
+		t.Fatalf("ammo = %d, want %d", w.Ammo, w.MagazineSize-12)
+	}
+}
+
+// TestScriptedAutoFireEmptiesMagazine holds the trigger until the
+// magazine runs dry and checks the fire rate and the automatic reload
+func TestScriptedAutoFireEmptiesMagazine(t *testing.T) {
+	source := input.NewScriptedSource(false,
+		input.ScriptStep{Ticks: 240, Command: input.Command{Fire: true}},
+	)
+	g := runScript(shootingRange(), source, 240)
+
+	w := g.GetWeapon()
+	if g.Stats.ShotsFired != w.MagazineSize {
+		t.Fatalf("shots fired = %d, want a full magazine of %d", g.Stats.ShotsFired, w.MagazineSize)
+	}
+	if w.Ammo != 0 || !w.IsReloading() {
+		t.Fatalf("ammo = %d reloading %v, want an empty magazine being reloaded", w.Ammo, w.IsReloading())
+	}
+}
+
+// TestScriptedReplayIsDeterministic runs the same script twice and checks
+// both runs end in identical state, so recorded input replays exactly
+func TestScriptedReplayIsDeterministic(t *testing.T) {
+	script := func() input.InputSource {
+		return input.NewScriptedSource(true,
+			input.ScriptStep{Ticks: 30, Command: input.Command{MoveForward: 1, LookDelta: rl.Vector2{X: 4}}},
+			input.ScriptStep{Ticks: 20, Command: input.Command{Fire: true, MoveRight: 1}},
+			input.ScriptStep{Ticks: 1, Command: input.Command{Jump: true, SelectSlot: 3}},
+			input.ScriptStep{Ticks: 80, Command: input.Command{Aim: true, LookDelta: rl.Vector2{X: -3, Y: 3}}},
+			input.ScriptStep{Ticks: 5, Command: input.Command{Fire: true, Aim: true}},
+			input.ScriptStep{Ticks: 1, Command: input.Command{SelectSlot: 1}},
+			input.ScriptStep{Ticks: 40, Command: input.Command{MoveForward: -1, Sprint: true}},
+		)
+	}
+
+	first := takeSnapshot(runScript(level.Default(), script(), 900))
+	second := takeSnapshot(runScript(level.Default(), script(), 900))
+	if first.Stats.ShotsFired == 0 || first.Stats.Explosions == 0 {
+		t.Fatalf("script did not exercise firing and explosions: %+v", first.Stats)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Fatalf("replays diverged:\nfirst  %+v\nsecond %+v", first, second)
+	}
+}
+
+// TestProjectilesExpireOutsideBounds fires a rocket out of the arena and
+// checks it is dropped once it leaves instead of flying on to its range
+func TestProjectilesExpireOutsideBounds(t *testing.T) {
+	lvl := shootingRange()
+	lvl.Boxes = nil
+	g := New(lvl)
+	g.Loadout.Equip(2)
+	g.Player.Pitch = 0.1 // Clear the floor
+
+	source := input.NewScriptedSource(false,
+		input.ScriptStep{Ticks: 1, Command: input.Command{Fire: true}},
+	)
+	for tick := 0; tick < 240; tick++ {
+		g.SavePreviousState()
+		g.Update(source.NextCommand(), TICK_DURATION)
+	}
+
+	if g.Stats.ShotsFired != 1 || g.Stats.Explosions != 0 {
+		t.Fatalf("stats = %+v, want one rocket fired that hit nothing", g.Stats)
+	}
+	if n := len(g.Projectiles.Projectiles); n != 0 {
+		t.Fatalf("%d projectiles still in flight outside the arena", n)
+	}
+	if n := g.Effects.Active(); n != 0 {
+		t.Fatalf("%d particles still alive, want the smoke trail to have stopped", n)
+	}
+}
+
+// TestBlockedFallLandsOnBounds walks the player off the edge of the floor
+// inside a larger bounds volume and checks they come to rest on the bounds
+// floor instead of hanging in midair with growing fall speed
+func TestBlockedFallLandsOnBounds(t *testing.T) {
+	lvl := shootingRange()
+	lvl.Boxes = nil
+	lvl.Bounds[0].Min.X = -15
+	lvl.Bounds[0].Max.X = 15
+	lvl.PlayerSpawn = level.Spawn{Position: rl.Vector3{X: 12, Y: 0}, Yaw: math.Pi / 2}
+
+	g := runScript(lvl, input.NewScriptedSource(false), 120)
+	p := g.Player
+	if p.Position.Y < lvl.Bounds[0].Min.Y {
+		t.Fatalf("player fell through the bounds floor to y %v", p.Position.Y)
+	}
+	if !p.Grounded || p.Velocity.Y != 0 {
+		t.Fatalf("grounded %v with vertical velocity %v, want standing on the bounds floor", p.Grounded, p.Velocity.Y)
+	}
+
+	// Walking into the side wall stops against it
+	source := input.NewScriptedSource(false, input.ScriptStep{Ticks: 120, Command: input.Command{MoveForward: 1}})
+	for tick := 0; tick < 120; tick++ {
+		g.SavePreviousState()
+		g.Update(source.NextCommand(), TICK_DURATION)
+	}
+	if p.Position.X > lvl.Bounds[0].Max.X || p.Velocity.X != 0 {
+		t.Fatalf("player at x %v moving %v, want stopped at the bounds wall", p.Position.X, p.Velocity.X)
+	}
+}
+
+// crateRange returns the shooting range with a light dynamic crate at the
+// given center
+func crateRange(center rl.Vector3) *level.Level {
+	lvl := shootingRange()
+	lvl.Boxes = []level.Box{
+		{Position: center, Size: rl.Vector3{X: 1, Y: 1, Z: 1}, Dynamic: true, Mass: 1},
+	}
+	return lvl
+}
+
+// overlapDepth returns how far two boxes overlap on their shallowest axis
+func overlapDepth(a, b rl.BoundingBox) float32 {
+	return min(
+		min(a.Max.X, b.Max.X)-max(a.Min.X, b.Min.X),
+		min(a.Max.Y, b.Max.Y)-max(a.Min.Y, b.Min.Y),
+		min(a.Max.Z, b.Max.Z)-max(a.Min.Z, b.Min.Z),
+	)
+}
+
+// TestPlayerPushesBodies walks the player into a crate and checks it is
+// shoved ahead of them rather than blocking them like a wall
+func TestPlayerPushesBodies(t *testing.T) {
+	lvl := crateRange(rl.Vector3{Y: 0.5, Z: 3})
+	source := input.NewScriptedSource(false, input.ScriptStep{Ticks: 90, Command: input.Command{MoveForward: 1}})
+	g := runScript(lvl, source, 90)
+
+	body := g.Bodies.Bodies[0]
+	if body.Position.Z > 2 {
+		t.Fatalf("crate at z %v, want pushed well past its start at z 3", body.Position.Z)
+	}
+	if g.Player.Position.Z > 3 {
+		t.Fatalf("player at z %v, want them to have followed the crate", g.Player.Position.Z)
+	}
+	if depth := overlapDepth(g.Player.GetCollisionBox(), body.GetBoundingBox()); depth > 0.01 {
+		t.Fatalf("crate overlaps the player by %v", depth)
+	}
+}
+
+// TestBodiesLandOnPlayer drops a crate on the player and checks it comes
+// to rest on their head instead of inside them
+func TestBodiesLandOnPlayer(t *testing.T) {
+	g := runScript(crateRange(rl.Vector3{Y: 4, Z: 5}), input.NewScriptedSource(false), 120)
+
+	body := g.Bodies.Bodies[0]
+	if depth := overlapDepth(g.Player.GetCollisionBox(), body.GetBoundingBox()); depth > 0.01 {
+		t.Fatalf("crate at y %v overlaps the player by %v", body.Position.Y, depth)
+	}
+	if body.Position.Y < 1 {
+		t.Fatalf("crate at y %v fell through the player", body.Position.Y)
+	}
+}
+
+// penetrationRange returns a level with a plywood panel of the given
+// thickness between the player and a single enemy
+func penetrationRange(thickness float32) *level.Level {
+	lvl := shootingRange()
+	lvl.PlayerSpawn.Position.Z = 7
+	lvl.EnemySpawns = []rl.Vector3{{}}
+	lvl.Materials = map[string]level.Material{"plywood": {Resistance: 20}}
+	lvl.Boxes = []level.Box{
+		{Position: rl.Vector3{Y: 1, Z: 4.5}, Size: rl.Vector3{X: 10, Y: 2, Z: thickness}, Material: "plywood"},
+	}
+	return lvl
+}
+
+// fireAtTorso fires one rifle round at the torso of the first enemy,
+// aiming where its patrol takes it on the firing tick, and returns the
+// direction it aimed along
+func fireAtTorso(lvl *level.Level) (*GameState, rl.Vector3) {
+	g := New(lvl)
+	probe := *g.Enemies.Enemies[0]
+	c := *g.Clock
+	c.Advance(TICK_DURATION)
+	probe.Update(&c)
+
+	torso := rl.Vector3Add(probe.Position, rl.Vector3{Y: 1.2})
+	aim := rl.Vector3Normalize(rl.Vector3Subtract(torso, g.Player.GetEyePosition()))
+	g.Player.Yaw = float32(math.Atan2(float64(aim.X), float64(aim.Z)))
+	g.Player.Pitch = float32(math.Asin(float64(aim.Y)))
+
+	g.SavePreviousState()
+	g.Update(input.Command{Fire: true}, TICK_DURATION)
+	return g, aim
+}
+
+// TestRoundsPenetrateThinCover shoots an enemy through a thin panel and
+// checks the round passes through with damage reduced by the power spent
+func TestRoundsPenetrateThinCover(t *testing.T) {
+	const thickness float32 = 0.1
+	g, aim := fireAtTorso(penetrationRange(thickness))
+
+	want := Stats{ShotsFired: 1, CubeHits: 1, EnemyHits: 1, Penetrations: 1}
+	if g.Stats != want {
+		t.Fatalf("stats = %+v, want %+v", g.Stats, want)
+	}
+	w := g.GetWeapon()
+	damage := enemy.MAX_HEALTH - g.Enemies.Enemies[0].Health
+	// The round crosses the panel at an angle, so it passes through more than its thickness
+	path := thickness / float32(math.Abs(float64(aim.Z)))
+	expected := w.PenetrationDamage(w.Penetration-20*path) * enemy.TORSO_DAMAGE_MULTIPLIER
+	if math.Abs(float64(damage-expected)) > 0.01 || damage >= w.Damage {
+		t.Fatalf("enemy took %v damage through cover, want %v (less than the full %v)", damage, expected, w.Damage)
+	}
+}
+
+// TestThickCoverStopsRounds checks a panel costing more than the weapon's
+// penetration power stops the round before it reaches the enemy
+func TestThickCoverStopsRounds(t *testing.T) {
+	const thickness float32 = 1.5
+	lvl := penetrationRange(thickness)
+	g, _ := fireAtTorso(lvl)
+
+	if cost := 20 * thickness; cost <= g.GetWeapon().Penetration {
+		t.Fatalf("panel costs %v, not more than the rifle's penetration %v", cost, g.GetWeapon().Penetration)
+	}
+	want := Stats{ShotsFired: 1, CubeHits: 1}
+	if g.Stats != want {
+		t.Fatalf("stats = %+v, want %+v", g.Stats, want)
+	}
+	if health := g.Enemies.Enemies[0].Health; health != enemy.MAX_HEALTH {
+		t.Fatalf("enemy health %v behind thick cover, want untouched", health)
+	}
+}
+
+// TestRecoilSettlesAtPitchLimit fires while looking almost straight up and
+// checks the view settles back where it was aimed, not below it by the
+// part of the kick the pitch limit swallowed
+func TestRecoilSettlesAtPitchLimit(t *testing.T) {
+	g := New(shootingRange())
+	g.Loadout.Equip(1)
+	g.Player.Pitch = player.MAX_PITCH - 0.01
+	yaw, pitch := g.Player.Yaw, g.Player.Pitch
+
+	source := input.NewScriptedSource(false, input.ScriptStep{Ticks: 1, Command: input.Command{Fire: true}})
+	for tick := 0; tick < 120; tick++ {
+		g.SavePreviousState()
+		g.Update(source.NextCommand(), TICK_DURATION)
+	}
+
+	if g.Stats.ShotsFired != 1 {
+		t.Fatalf("fired %d shots, want 1", g.Stats.ShotsFired)
+	}
+	if math.Abs(float64(g.Player.Pitch-pitch)) > 1e-4 || math.Abs(float64(g.Player.Yaw-yaw)) > 1e-4 {
+		t.Fatalf("view settled at yaw %v pitch %v, want back at yaw %v pitch %v", g.Player.Yaw, g.Player.Pitch, yaw, pitch)
+	}
+}
+
+// TestLevelSetsEnemyRespawn checks the level's respawn rules reach the
+// enemy manager and unset ones keep the defaults
+func TestLevelSetsEnemyRespawn(t *testing.T) {
+	if got, want := New(level.Default()).Enemies.Config, enemy.DefaultConfig(); got != want {
+		t.Fatalf("default level config = %+v, want %+v", got, want)
+	}
+
+	lvl := level.Default()
+	lvl.EnemyRespawn = level.EnemyRespawn{Delay: 0.5, Location: level.ENEMY_RESPAWN_NEXT}
+	want := enemy.Config{RespawnEnabled: true, RespawnDelay: 0.5, RespawnLocation: enemy.RESPAWN_AT_NEXT_POINT}
+	if got := New(lvl).Enemies.Config; got != want {
+		t.Fatalf("config = %+v, want %+v", got, want)
+	}
+
+	// Killed enemies stay dead when respawning is off
+	disabled := false
+	lvl = level.Default()
+	lvl.EnemyRespawn.Enabled = &disabled
+	g := New(lvl)
+	for _, e := range g.Enemies.Enemies {
+		g.Enemies.Damage(e, e.Health)
+	}
+	for tick := 0; tick < 600; tick++ {
+		g.SavePreviousState()
+		g.Update(input.Command{}, TICK_DURATION)
+	}
+	if n := len(g.Enemies.Enemies); n != 0 {
+		t.Fatalf("%d enemies back after being killed with respawning off", n)
+	}
+}
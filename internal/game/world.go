@@ -0,0 +1,138 @@
+package game
+
+import (
+	"fps/internal/enemy"
+	"fps/internal/level"
+	"fps/internal/physics"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// ApplyWorldRules enforces the level's bounds and kill volumes on the player
+// and every enemy. Runs after movement so positions are final for the tick.
+func (g *GameState) ApplyWorldRules() {
+	g.applyPlayerWorldRules()
+	for _, e := range g.Enemies.Enemies {
+		g.applyEnemyWorldRules(e)
+	}
+	for _, b := range g.Bodies.Bodies {
+		g.applyBodyWorldRules(b)
+	}
+}
+
+// applyBodyWorldRules puts dynamic cubes that leave the bounds or fall into
+// a kill volume back where the level placed them
+func (g *GameState) applyBodyWorldRules(b *physics.RigidBody) {
+	if g.Level.InBounds(b.Position) && g.Level.KillVolumeAt(b.Position) == nil {
+		return
+	}
+	b.Position = g.Level.Boxes[b.Index].Position
+	b.PrevPosition = b.Position
+	b.Velocity = rl.Vector3{}
+	g.Cubes[b.Index] = b.Position
+}
+
+// RespawnPlayer returns the player to the level spawn with full health
+func (g *GameState) RespawnPlayer() {
+	spawn := g.Level.PlayerSpawn
+	g.Player.Respawn(spawn.Position, spawn.Yaw)
+}
+
+// applyPlayerWorldRules handles the player leaving bounds or entering kill volumes
+func (g *GameState) applyPlayerWorldRules() {
+	p := g.Player
+	deltaTime := g.Clock.Delta
+
+	if !g.Level.InBounds(p.Position) {
+		switch g.Level.OutOfBounds.Action {
+		case level.OUT_OF_BOUNDS_BLOCK:
+			var blocked [3]bool
+			p.Position, blocked = g.blockAtBounds(p.PrevPosition, p.Position)
+			// Stop pushing into the boundary; a blocked fall lands on it
+			if blocked[0] {
+				p.Velocity.X = 0
+			}
+			if blocked[1] {
+				if p.Velocity.Y < 0 {
+					p.Grounded = true
+				}
+				p.Velocity.Y = 0
+			}
+			if blocked[2] {
+				p.Velocity.Z = 0
+			}
+		case level.OUT_OF_BOUNDS_RESPAWN:
+			p.TakeDamage(p.Health)
+		case level.OUT_OF_BOUNDS_DAMAGE:
+			p.TakeDamage(g.Level.OutOfBounds.Damage * deltaTime)
+		}
+	}
+
+	if kill := g.Level.KillVolumeAt(p.Position); kill != nil {
+		p.TakeDamage(killVolumeDamage(kill, p.Health, deltaTime))
+	}
+
+	if !p.IsAlive() {
+		g.RespawnPlayer()
+	}
+}
+
+// applyEnemyWorldRules handles an enemy leaving bounds or entering kill volumes
+func (g *GameState) applyEnemyWorldRules(e *enemy.Enemy) {
+	if !e.IsAlive() {
+		return
+	}
+	deltaTime := g.Clock.Delta
+
+	if !g.Level.InBounds(e.Position) {
+		switch g.Level.OutOfBounds.Action {
+		case level.OUT_OF_BOUNDS_BLOCK:
+			e.Position, _ = g.blockAtBounds(e.PrevPosition, e.Position)
+		case level.OUT_OF_BOUNDS_RESPAWN:
+			g.Enemies.Damage(e, e.Health)
+		case level.OUT_OF_BOUNDS_DAMAGE:
+			g.Enemies.Damage(e, g.Level.OutOfBounds.Damage*deltaTime)
+		}
+	}
+
+	if kill := g.Level.KillVolumeAt(e.Position); kill != nil {
+		g.Enemies.Damage(e, killVolumeDamage(kill, e.Health, deltaTime))
+	}
+}
+
+// blockAtBounds keeps a move inside the world, applying each axis of the
+// move in turn and keeping only those that stay in bounds, so the mover
+// slides along walls and comes to rest on the bounds floor. Returns the
+// resulting position and which of the X, Y and Z axes were blocked.
+func (g *GameState) blockAtBounds(from, to rl.Vector3) (rl.Vector3, [3]bool) {
+	var blocked [3]bool
+	if !g.Level.InBounds(from) {
+		return to, blocked // Already outside, so there is no boundary to hold
+	}
+
+	position := from
+	for axis := 0; axis < 3; axis++ {
+		candidate := position
+		switch axis {
+		case 0:
+			candidate.X = to.X
+		case 1:
+			candidate.Y = to.Y
+		case 2:
+			candidate.Z = to.Z
+		}
+		if g.Level.InBounds(candidate) {
+			position = candidate
+		} else {
+			blocked[axis] = true
+		}
+	}
+	return position, blocked
+}
+
+// killVolumeDamage returns the damage a kill volume deals this tick
+func killVolumeDamage(kill *level.KillVolume, health, deltaTime float32) float32 {
+	if kill.Damage == 0 {
+		return health
+	}
+	return kill.Damage * deltaTime
+}
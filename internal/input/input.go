@@ -1,80 +1,50 @@
 package input
 
 import (
-	"math"
-
-	rl "github.com/gen2brain/raylib-go/raylib"
+	"fps/internal/physics"
 	"fps/internal/player"
+	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
 // Constants for input behavior
 const (
-	MOVE_SPEED        = 5.0
 	MOUSE_SENSITIVITY = 0.003
 )
 
-// HandleMouseLook processes mouse movement for camera rotation
-func HandleMouseLook(p *player.Player, deltaTime float32) {
-	mouseDelta := rl.GetMouseDelta()
-
-	// Update rotation based on mouse movement
-	p.Yaw -= mouseDelta.X * MOUSE_SENSITIVITY
-	p.Pitch -= mouseDelta.Y * MOUSE_SENSITIVITY
+// HandleMouseLook applies the command's mouse movement to camera rotation.
+// sensitivity scales MOUSE_SENSITIVITY, so zoomed views turn slower.
+func HandleMouseLook(p *player.Player, cmd Command, sensitivity float32) {
+	mouseDelta := cmd.LookDelta
+	scale := MOUSE_SENSITIVITY * sensitivity
 
-	// Clamp pitch to prevent over-rotation
-	maxPitch := float32(math.Pi/2 - 0.1)
-	if p.Pitch > maxPitch {
-		p.Pitch = maxPitch
-	} else if p.Pitch < -maxPitch {
-		p.Pitch = -maxPitch
-	}
+	// Update rotation based on mouse movement; the player clamps pitch
+	p.Rotate(-mouseDelta.X*scale, -mouseDelta.Y*scale)
 }
 
-// HandleMovement processes WASD movement input
-func HandleMovement(p *player.Player, deltaTime float32) {
-	moveSpeed := MOVE_SPEED * deltaTime
-
-	// Get movement vectors
-	forward := p.GetForwardVector()
+// HandleMovement applies the command's movement axes to the player,
+// colliding with the given world geometry
+func HandleMovement(p *player.Player, cmd Command, world physics.Broadphase, deltaTime float32) {
+	// Get movement vectors on the ground plane
+	forward := p.GetFlatForwardVector()
 	right := p.GetRightVector()
 
-	// Movement input
-	if rl.IsKeyDown(rl.KeyW) {
-		p.Position = rl.Vector3Add(p.Position, rl.Vector3Scale(forward, moveSpeed))
-	}
-	if rl.IsKeyDown(rl.KeyS) {
-		p.Position = rl.Vector3Subtract(p.Position, rl.Vector3Scale(forward, moveSpeed))
-	}
-	if rl.IsKeyDown(rl.KeyD) {
-		p.Position = rl.Vector3Add(p.Position, rl.Vector3Scale(right, moveSpeed))
-	}
-	if rl.IsKeyDown(rl.KeyA) {
-		p.Position = rl.Vector3Subtract(p.Position, rl.Vector3Scale(right, moveSpeed))
-	}
-
-	// Keep player anchored to the ground plane (Y = 1)
-	p.Position.Y = 1.0
+	// Movement input; the player normalizes diagonals
+	wishDir := rl.Vector3Scale(forward, cmd.MoveForward)
+	wishDir = rl.Vector3Add(wishDir, rl.Vector3Scale(right, cmd.MoveRight))
 
-	// Keep player within world boundaries
-	worldSize := float32(10.0)
-	if p.Position.X > worldSize {
-		p.Position.X = worldSize
-	}
-	if p.Position.X < -worldSize {
-		p.Position.X = -worldSize
-	}
-	if p.Position.Z > worldSize {
-		p.Position.Z = worldSize
-	}
-	if p.Position.Z < -worldSize {
-		p.Position.Z = -worldSize
-	}
+	p.Move(player.MoveInput{
+		WishDir: wishDir,
+		Jump:    cmd.Jump,
+		Crouch:  cmd.Crouch,
+		Sprint:  cmd.Sprint && cmd.MoveForward > 0, // Sprint only while moving forward
+	}, world, deltaTime)
 }
 
-// HandleSystemInput processes system-level input (cursor toggle, exit)
-func HandleSystemInput() bool {
-	// Toggle cursor capture with Tab
-	if rl.IsKeyPressed(rl.KeyTab) {
+// HandleSystemInput processes system-level commands (cursor toggle, exit)
+// and reports whether exit was requested
+func HandleSystemInput(cmd Command) bool {
+	// Toggle cursor capture
+	if cmd.ToggleCursor {
 		if rl.IsCursorHidden() {
 			rl.EnableCursor()
 		} else {
@@ -82,6 +52,5 @@ func HandleSystemInput() bool {
 		}
 	}
 
-	// Exit with Escape
-	return rl.IsKeyPressed(rl.KeyEscape)
-} 
\ No newline at end of file
+	return cmd.Quit
+}
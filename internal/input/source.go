@@ -0,0 +1,167 @@
+package input
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Command is the player input consumed by a single simulation tick
+type Command struct {
+	MoveForward    float32    // -1 (back) to 1 (forward)
+	MoveRight      float32    // -1 (left) to 1 (right)
+	LookDelta      rl.Vector2 // Mouse movement in pixels since the last tick
+	Jump           bool
+	Crouch         bool // Held
+	Sprint         bool // Held
+	Fire           bool // Trigger held, or pulled since the last tick
+	Aim            bool // Held
+	Reload         bool
+	ToggleFireMode bool
+	SelectSlot     int // Weapon slot to switch to, counting from 1; 0 keeps the current weapon
+	CycleWeapon    int // -1 or 1 to switch to the previous or next weapon
+	TogglePause    bool
+	ToggleCursor   bool
+	Quit           bool
+}
+
+// WEAPON_SLOT_KEYS select loadout slots in order
+var WEAPON_SLOT_KEYS = []int32{rl.KeyOne, rl.KeyTwo, rl.KeyThree, rl.KeyFour}
+
+// InputSource produces one Command per simulation tick
+type InputSource interface {
+	NextCommand() Command
+}
+
+// RaylibSource reads keyboard and mouse state from raylib. Sample must be
+// called once per rendered frame; presses and mouse movement accumulate until
+// the next tick consumes them, so no input is lost or repeated when a frame
+// runs zero or several ticks.
+type RaylibSource struct {
+	pending  Command
+	fireHeld bool // Trigger state on the latest frame
+}
+
+// NewRaylibSource creates an input source backed by the raylib window
+func NewRaylibSource() *RaylibSource {
+	return &RaylibSource{}
+}
+
+// Sample polls raylib for the current frame's input
+func (s *RaylibSource) Sample() {
+	// Held keys reflect the latest frame
+	s.pending.MoveForward = 0
+	s.pending.MoveRight = 0
+	if rl.IsKeyDown(rl.KeyW) {
+		s.pending.MoveForward++
+	}
+	if rl.IsKeyDown(rl.KeyS) {
+		s.pending.MoveForward--
+	}
+	if rl.IsKeyDown(rl.KeyD) {
+		s.pending.MoveRight++
+	}
+	if rl.IsKeyDown(rl.KeyA) {
+		s.pending.MoveRight--
+	}
+	s.pending.Crouch = rl.IsKeyDown(rl.KeyLeftControl) || rl.IsKeyDown(rl.KeyC)
+	s.pending.Sprint = rl.IsKeyDown(rl.KeyLeftShift)
+	s.pending.Aim = rl.IsMouseButtonDown(rl.MouseRightButton) && rl.IsCursorHidden()
+
+	// Mouse movement and presses accumulate until consumed
+	s.pending.LookDelta = rl.Vector2Add(s.pending.LookDelta, rl.GetMouseDelta())
+	if rl.IsKeyPressed(rl.KeySpace) {
+		s.pending.Jump = true
+	}
+	// The trigger is held until released, and a click shorter than a tick still counts
+	s.fireHeld = rl.IsMouseButtonDown(rl.MouseLeftButton) && rl.IsCursorHidden()
+	if s.fireHeld || (rl.IsMouseButtonPressed(rl.MouseLeftButton) && rl.IsCursorHidden()) {
+		s.pending.Fire = true
+	}
+	if rl.IsKeyPressed(rl.KeyB) {
+		s.pending.ToggleFireMode = true
+	}
+	for i, key := range WEAPON_SLOT_KEYS {
+		if rl.IsKeyPressed(key) {
+			s.pending.SelectSlot = i + 1
+		}
+	}
+	if wheel := rl.GetMouseWheelMove(); wheel > 0 {
+		s.pending.CycleWeapon = -1
+	} else if wheel < 0 {
+		s.pending.CycleWeapon = 1
+	}
+	if rl.IsKeyPressed(rl.KeyR) {
+		s.pending.Reload = true
+	}
+	if rl.IsKeyPressed(rl.KeyP) {
+		s.pending.TogglePause = true
+	}
+	if rl.IsKeyPressed(rl.KeyTab) {
+		s.pending.ToggleCursor = true
+	}
+	if rl.IsKeyPressed(rl.KeyEscape) {
+		s.pending.Quit = true
+	}
+}
+
+// NextCommand returns the accumulated input and clears one-shot events
+func (s *RaylibSource) NextCommand() Command {
+	cmd := s.pending
+	s.pending.LookDelta = rl.Vector2{}
+	s.pending.Jump = false
+	s.pending.Fire = s.fireHeld
+	s.pending.Reload = false
+	s.pending.ToggleFireMode = false
+	s.pending.SelectSlot = 0
+	s.pending.CycleWeapon = 0
+	s.pending.TogglePause = false
+	s.pending.ToggleCursor = false
+	s.pending.Quit = false
+	return cmd
+}
+
+// ScriptStep holds a command for a number of consecutive ticks
+type ScriptStep struct {
+	Ticks   int
+	Command Command
+}
+
+// ScriptedSource replays a fixed sequence of commands, for bots, replays and tests
+type ScriptedSource struct {
+	Steps []ScriptStep
+	Loop  bool
+	step  int
+	tick  int
+}
+
+// NewScriptedSource creates a source that plays the given steps in order
+func NewScriptedSource(loop bool, steps ...ScriptStep) *ScriptedSource {
+	return &ScriptedSource{
+		Steps: steps,
+		Loop:  loop,
+	}
+}
+
+// NextCommand returns the command for the current tick of the script
+func (s *ScriptedSource) NextCommand() Command {
+	for skipped := 0; !s.Done() && s.tick >= s.Steps[s.step].Ticks; skipped++ {
+		if skipped > len(s.Steps) {
+			return Command{} // Every step is empty
+		}
+		s.step++
+		s.tick = 0
+		if s.step == len(s.Steps) && s.Loop {
+			s.step = 0
+		}
+	}
+	if s.Done() {
+		return Command{}
+	}
+
+	s.tick++
+	return s.Steps[s.step].Command
+}
+
+// Done reports whether a non-looping script has run out of steps
+func (s *ScriptedSource) Done() bool {
+	return s.step >= len(s.Steps)
+}
@@ -0,0 +1,214 @@
+package level
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Level describes a playable layout loaded from a JSON file. Vectors are
+// written as {"x": 0, "y": 0, "z": 0} and colors as {"r": 0, "g": 0, "b": 0, "a": 255}.
+type Level struct {
+	Name         string              `json:"name"`
+	Ground       Ground              `json:"ground"`
+	Bounds       []Volume            `json:"bounds"` // The playable space is the union of these volumes
+	OutOfBounds  OutOfBounds         `json:"outOfBounds"`
+	KillVolumes  []KillVolume        `json:"killVolumes"`
+	PlayerSpawn  Spawn               `json:"playerSpawn"`
+	EnemySpawns  []rl.Vector3        `json:"enemySpawns"`
+	EnemyRespawn EnemyRespawn        `json:"enemyRespawn"`
+	Materials    map[string]Material `json:"materials"`
+	Boxes        []Box               `json:"boxes"`
+}
+
+// Ground describes the floor plane centered on the origin
+type Ground struct {
+	Width     float32  `json:"width"` // Extent along X
+	Depth     float32  `json:"depth"` // Extent along Z
+	Color     rl.Color `json:"color"`
+	GridColor rl.Color `json:"gridColor"`
+}
+
+// Spawn is a position with a facing direction
+type Spawn struct {
+	Position rl.Vector3 `json:"position"`
+	Yaw      float32    `json:"yaw"` // Radians; 0 faces +Z
+}
+
+// Material holds the visual and physical properties shared by boxes
+type Material struct {
+	Color      rl.Color `json:"color"`
+	Resistance float32  `json:"resistance"` // Penetration cost per unit of thickness; zero stops every round
+}
+
+// Box is a static axis-aligned box in the world
+type Box struct {
+	Position rl.Vector3 `json:"position"` // Center of the box
+	Size     rl.Vector3 `json:"size"`
+	Material string     `json:"material"`
+	Color    *rl.Color  `json:"color"`   // Overrides the material color when set
+	Dynamic  bool       `json:"dynamic"` // Simulated as a rigid body instead of fixed in place
+	Mass     float32    `json:"mass"`    // Rigid body mass; defaults to the box volume
+}
+
+// Load reads and validates a level file
+func Load(path string) (*Level, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read level: %w", err)
+	}
+
+	var lvl Level
+	if err := json.Unmarshal(data, &lvl); err != nil {
+		return nil, fmt.Errorf("parse level %s: %w", path, err)
+	}
+	if err := lvl.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid level %s: %w", path, err)
+	}
+	return &lvl, nil
+}
+
+// Validate checks the level for values the game cannot handle
+func (l *Level) Validate() error {
+	if l.Ground.Width <= 0 || l.Ground.Depth <= 0 {
+		return fmt.Errorf("ground size must be positive")
+	}
+	if len(l.Bounds) == 0 {
+		return fmt.Errorf("at least one bounds volume is required")
+	}
+	for i, volume := range l.Bounds {
+		if err := volume.Validate(); err != nil {
+			return fmt.Errorf("bounds %d: %w", i, err)
+		}
+	}
+	if err := l.OutOfBounds.Validate(); err != nil {
+		return fmt.Errorf("outOfBounds: %w", err)
+	}
+	for i, kill := range l.KillVolumes {
+		if err := kill.Validate(); err != nil {
+			return fmt.Errorf("kill volume %d: %w", i, err)
+		}
+		if kill.Damage < 0 {
+			return fmt.Errorf("kill volume %d: damage must not be negative", i)
+		}
+	}
+	if !l.InBounds(l.PlayerSpawn.Position) {
+		return fmt.Errorf("player spawn is outside the world bounds")
+	}
+	for i, spawn := range l.EnemySpawns {
+		if !l.InBounds(spawn) {
+			return fmt.Errorf("enemy spawn %d is outside the world bounds", i)
+		}
+	}
+	if err := l.EnemyRespawn.Validate(); err != nil {
+		return fmt.Errorf("enemyRespawn: %w", err)
+	}
+	for i, box := range l.Boxes {
+		if box.Size.X <= 0 || box.Size.Y <= 0 || box.Size.Z <= 0 {
+			return fmt.Errorf("box %d: size must be positive", i)
+		}
+		if _, ok := l.Materials[box.Material]; box.Material != "" && !ok {
+			return fmt.Errorf("box %d: unknown material %q", i, box.Material)
+		}
+		if box.Mass < 0 {
+			return fmt.Errorf("box %d: mass must not be negative", i)
+		}
+	}
+	for name, material := range l.Materials {
+		if material.Resistance < 0 {
+			return fmt.Errorf("material %q: resistance must not be negative", name)
+		}
+	}
+	return nil
+}
+
+// InBounds reports whether the point lies inside any of the bounds volumes
+func (l *Level) InBounds(p rl.Vector3) bool {
+	for _, volume := range l.Bounds {
+		if volume.Contains(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// KillVolumeAt returns the first kill volume containing the point, or nil
+func (l *Level) KillVolumeAt(p rl.Vector3) *KillVolume {
+	for i := range l.KillVolumes {
+		if l.KillVolumes[i].Contains(p) {
+			return &l.KillVolumes[i]
+		}
+	}
+	return nil
+}
+
+// BoxColor resolves the display color of a box from its override or material
+func (l *Level) BoxColor(box Box) rl.Color {
+	if box.Color != nil {
+		return *box.Color
+	}
+	if material, ok := l.Materials[box.Material]; ok {
+		return material.Color
+	}
+	return rl.Gray
+}
+
+// BoxMass returns the rigid body mass of a box, defaulting to its volume
+func (l *Level) BoxMass(box Box) float32 {
+	if box.Mass > 0 {
+		return box.Mass
+	}
+	return box.Size.X * box.Size.Y * box.Size.Z
+}
+
+// BoxResistance returns the penetration resistance of a box's material.
+// Boxes without a material cannot be shot through.
+func (l *Level) BoxResistance(box Box) float32 {
+	if material, ok := l.Materials[box.Material]; ok {
+		return material.Resistance
+	}
+	return 0
+}
+
+// Default returns the built-in arena used when no level file is given
+func Default() *Level {
+	return &Level{
+		Name: "Arena",
+		Ground: Ground{
+			Width:     20,
+			Depth:     20,
+			Color:     rl.NewColor(34, 139, 34, 255), // Forest green
+			GridColor: rl.NewColor(0, 100, 0, 100),   // Darker green, semi-transparent
+		},
+		Bounds: []Volume{{
+			Type: VOLUME_BOX,
+			Min:  rl.Vector3{X: -10, Y: -1, Z: -10},
+			Max:  rl.Vector3{X: 10, Y: 10, Z: 10},
+		}},
+		OutOfBounds: OutOfBounds{Action: OUT_OF_BOUNDS_BLOCK},
+		PlayerSpawn: Spawn{
+			Position: rl.Vector3{X: 0, Y: 0, Z: 8},
+			Yaw:      math.Pi, // Face the center of the arena
+		},
+		EnemySpawns: []rl.Vector3{
+			{X: 0, Y: 0, Z: 0},
+			{X: -7, Y: 0, Z: 0},
+			{X: 7, Y: 0, Z: 0},
+		},
+		Boxes: []Box{
+			{Position: rl.Vector3{X: 5, Y: 0.5, Z: 5}, Size: rl.Vector3{X: 1, Y: 1, Z: 1}, Color: colorPtr(rl.Red)},
+			{Position: rl.Vector3{X: -5, Y: 0.5, Z: 5}, Size: rl.Vector3{X: 1, Y: 1, Z: 1}, Color: colorPtr(rl.Blue)},
+			{Position: rl.Vector3{X: 5, Y: 0.5, Z: -5}, Size: rl.Vector3{X: 1, Y: 1, Z: 1}, Color: colorPtr(rl.Yellow)},
+			{Position: rl.Vector3{X: -5, Y: 0.5, Z: -5}, Size: rl.Vector3{X: 1, Y: 1, Z: 1}, Color: colorPtr(rl.Purple)},
+			{Position: rl.Vector3{X: 0, Y: 0.5, Z: 10}, Size: rl.Vector3{X: 1, Y: 1, Z: 1}, Color: colorPtr(rl.Orange)},
+		},
+	}
+}
+
+// colorPtr returns a pointer to a copy of the color for optional overrides
+func colorPtr(c rl.Color) *rl.Color {
+	return &c
+}
@@ -0,0 +1,51 @@
+package level
+
+import (
+	"strings"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// TestShippedLevelsValidate checks the built-in arena and the example level
+// pass validation
+func TestShippedLevelsValidate(t *testing.T) {
+	if err := Default().Validate(); err != nil {
+		t.Fatalf("default level: %v", err)
+	}
+	if _, err := Load("../../assets/levels/example.json"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestValidateRejectsSpawnsOutsideBounds checks that player and enemy spawns
+// must lie inside the bounds volumes
+func TestValidateRejectsSpawnsOutsideBounds(t *testing.T) {
+	outside := rl.Vector3{X: 50}
+
+	lvl := Default()
+	lvl.PlayerSpawn.Position = outside
+	if err := lvl.Validate(); err == nil || !strings.Contains(err.Error(), "player spawn") {
+		t.Fatalf("player spawn outside bounds: got %v", err)
+	}
+
+	lvl = Default()
+	lvl.EnemySpawns = append(lvl.EnemySpawns, outside)
+	if err := lvl.Validate(); err == nil || !strings.Contains(err.Error(), "enemy spawn 3") {
+		t.Fatalf("enemy spawn outside bounds: got %v", err)
+	}
+}
+
+// TestValidateRejectsBadEnemyRespawn checks respawn settings are checked
+func TestValidateRejectsBadEnemyRespawn(t *testing.T) {
+	lvl := Default()
+	lvl.EnemyRespawn = EnemyRespawn{Location: "anywhere"}
+	if err := lvl.Validate(); err == nil || !strings.Contains(err.Error(), "enemyRespawn") {
+		t.Fatalf("unknown respawn location: got %v", err)
+	}
+
+	lvl.EnemyRespawn = EnemyRespawn{Delay: -1}
+	if err := lvl.Validate(); err == nil || !strings.Contains(err.Error(), "enemyRespawn") {
+		t.Fatalf("negative respawn delay: got %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package level
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Volume types
+const (
+	VOLUME_BOX    = "box"
+	VOLUME_CONVEX = "convex"
+)
+
+// Out-of-bounds actions
+const (
+	OUT_OF_BOUNDS_BLOCK   = "block"   // Stop movement at the boundary
+	OUT_OF_BOUNDS_RESPAWN = "respawn" // Kill and respawn the entity
+	OUT_OF_BOUNDS_DAMAGE  = "damage"  // Apply damage every second spent outside
+)
+
+// Enemy respawn locations
+const (
+	ENEMY_RESPAWN_ORIGIN = "origin" // Where the enemy first spawned
+	ENEMY_RESPAWN_NEXT   = "next"   // Cycle through the level's enemy spawns
+)
+
+// Volume is a region of space: either an axis-aligned box or a convex
+// polygon on the XZ plane extruded between MinY and MaxY
+type Volume struct {
+	Type   string       `json:"type"`
+	Min    rl.Vector3   `json:"min"`    // Box only
+	Max    rl.Vector3   `json:"max"`    // Box only
+	Points []rl.Vector2 `json:"points"` // Convex only; X and Y hold world X and Z
+	MinY   float32      `json:"minY"`   // Convex only
+	MaxY   float32      `json:"maxY"`   // Convex only
+}
+
+// KillVolume damages anything inside it
+type KillVolume struct {
+	Volume
+	Damage float32 `json:"damage"` // Damage per second; 0 kills instantly
+}
+
+// EnemyRespawn describes how killed enemies come back
+type EnemyRespawn struct {
+	Enabled  *bool   `json:"enabled"`  // Defaults to true
+	Delay    float32 `json:"delay"`    // Seconds after a corpse is removed; zero uses the game default
+	Location string  `json:"location"` // One of the ENEMY_RESPAWN_* locations; empty means origin
+}
+
+// Validate checks the respawn settings
+func (r EnemyRespawn) Validate() error {
+	if r.Delay < 0 {
+		return fmt.Errorf("delay must not be negative")
+	}
+	switch r.Location {
+	case "", ENEMY_RESPAWN_ORIGIN, ENEMY_RESPAWN_NEXT:
+		return nil
+	}
+	return fmt.Errorf("unknown location %q", r.Location)
+}
+
+// OutOfBounds describes what happens to entities that leave the world bounds
+type OutOfBounds struct {
+	Action string  `json:"action"`
+	Damage float32 `json:"damage"` // Damage per second for the damage action
+}
+
+// Contains reports whether the point lies inside the volume
+func (v Volume) Contains(p rl.Vector3) bool {
+	switch v.Type {
+	case VOLUME_BOX:
+		return p.X >= v.Min.X && p.X <= v.Max.X &&
+			p.Y >= v.Min.Y && p.Y <= v.Max.Y &&
+			p.Z >= v.Min.Z && p.Z <= v.Max.Z
+	case VOLUME_CONVEX:
+		if p.Y < v.MinY || p.Y > v.MaxY {
+			return false
+		}
+		// Inside a convex polygon the point is on the same side of every edge
+		sign := float32(0)
+		for i := range v.Points {
+			a := v.Points[i]
+			b := v.Points[(i+1)%len(v.Points)]
+			cross := (b.X-a.X)*(p.Z-a.Y) - (b.Y-a.Y)*(p.X-a.X)
+			if cross == 0 {
+				continue
+			}
+			if sign == 0 {
+				sign = cross
+			} else if (cross > 0) != (sign > 0) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// Validate checks that the volume is well formed
+func (v Volume) Validate() error {
+	switch v.Type {
+	case VOLUME_BOX:
+		if v.Min.X >= v.Max.X || v.Min.Y >= v.Max.Y || v.Min.Z >= v.Max.Z {
+			return fmt.Errorf("box min must be less than max on every axis")
+		}
+	case VOLUME_CONVEX:
+		if len(v.Points) < 3 {
+			return fmt.Errorf("convex volume needs at least 3 points")
+		}
+		if v.MinY >= v.MaxY {
+			return fmt.Errorf("convex minY must be less than maxY")
+		}
+		if !isConvex(v.Points) {
+			return fmt.Errorf("convex volume points do not form a convex polygon")
+		}
+	default:
+		return fmt.Errorf("unknown volume type %q", v.Type)
+	}
+	return nil
+}
+
+// Validate checks that the action is known
+func (o OutOfBounds) Validate() error {
+	switch o.Action {
+	case OUT_OF_BOUNDS_BLOCK, OUT_OF_BOUNDS_RESPAWN:
+		return nil
+	case OUT_OF_BOUNDS_DAMAGE:
+		if o.Damage <= 0 {
+			return fmt.Errorf("damage action needs a positive damage rate")
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown out-of-bounds action %q", o.Action)
+}
+
+// isConvex reports whether the polygon turns the same way at every vertex
+func isConvex(points []rl.Vector2) bool {
+	sign := float32(0)
+	for i := range points {
+		a := points[i]
+		b := points[(i+1)%len(points)]
+		c := points[(i+2)%len(points)]
+		cross := (b.X-a.X)*(c.Y-b.Y) - (b.Y-a.Y)*(c.X-b.X)
+		if cross == 0 {
+			continue
+		}
+		if sign == 0 {
+			sign = cross
+		} else if (cross > 0) != (sign > 0) {
+			return false
+		}
+	}
+	return sign != 0
+}
@@ -0,0 +1,197 @@
+package physics
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Constants for character collision
+const (
+	MAX_SLIDE_ITERATIONS = 4     // Surfaces a single move can slide along
+	COLLISION_SKIN       = 0.001 // Gap kept between a mover and what it touches
+	FLOOR_NORMAL_MIN_Y   = 0.7   // Normals steeper than this count as floor
+)
+
+// SlideResult describes the outcome of a MoveAndSlide call
+type SlideResult struct {
+	Motion     rl.Vector3 // Displacement actually applied
+	HitFloor   bool
+	HitCeiling bool
+	HitWall    bool
+}
+
+// MoveAndSlide sweeps an axis-aligned box along motion against static
+// obstacles. On contact the box stops just short of the surface and the
+// remaining motion is projected onto it, so movement slides along walls
+// instead of stopping dead.
+func MoveAndSlide(box rl.BoundingBox, motion rl.Vector3, world Broadphase) SlideResult {
+	var result SlideResult
+	half := rl.Vector3Scale(rl.Vector3Subtract(box.Max, box.Min), 0.5)
+	start := rl.Vector3Add(box.Min, half)
+
+	// Sliding never leaves the box swept along the full motion, so only
+	// obstacles near that region can be touched
+	obstacles := world.QueryBox(sweptBounds(box, motion))
+
+	// Resolve any overlap left over from teleports or spawns first
+	center := depenetrate(start, half, obstacles)
+
+	remaining := motion
+	for i := 0; i < MAX_SLIDE_ITERATIONS; i++ {
+		length := rl.Vector3Length(remaining)
+		if length < COLLISION_SKIN {
+			break
+		}
+
+		t, normal, hit := sweepBox(center, half, remaining, obstacles)
+		if !hit {
+			center = rl.Vector3Add(center, remaining)
+			break
+		}
+
+		// Advance up to the contact, backing off by the skin distance
+		advance := float32(math.Max(float64(t*length-COLLISION_SKIN), 0)) / length
+		center = rl.Vector3Add(center, rl.Vector3Scale(remaining, advance))
+
+		if normal.Y >= FLOOR_NORMAL_MIN_Y {
+			result.HitFloor = true
+		} else if normal.Y <= -FLOOR_NORMAL_MIN_Y {
+			result.HitCeiling = true
+		} else {
+			result.HitWall = true
+		}
+
+		// Slide the rest of the motion along the surface
+		remaining = rl.Vector3Scale(remaining, 1-advance)
+		remaining = rl.Vector3Subtract(remaining, rl.Vector3Scale(normal, rl.Vector3DotProduct(remaining, normal)))
+	}
+
+	result.Motion = rl.Vector3Subtract(center, start)
+	return result
+}
+
+// sweepBox finds the earliest obstacle hit by a box moving along motion.
+// Returns the hit time as a fraction of motion and the surface normal.
+func sweepBox(center, half, motion rl.Vector3, obstacles []rl.BoundingBox) (float32, rl.Vector3, bool) {
+	bestT := float32(math.Inf(1))
+	var bestNormal rl.Vector3
+	for _, obstacle := range obstacles {
+		// Expanding the obstacle by the mover's extents reduces the sweep to a ray cast
+		expanded := rl.BoundingBox{
+			Min: rl.Vector3Subtract(obstacle.Min, half),
+			Max: rl.Vector3Add(obstacle.Max, half),
+		}
+		t, normal, hit := segmentBoxEntry(center, motion, expanded)
+		if hit && t < bestT {
+			bestT = t
+			bestNormal = normal
+		}
+	}
+	if math.IsInf(float64(bestT), 1) {
+		return 0, rl.Vector3{}, false
+	}
+	return bestT, bestNormal, true
+}
+
+// segmentBoxEntry intersects the segment origin + t*motion (t in [0, 1]) with
+// a box using the slab method and returns the entry time and face normal
+func segmentBoxEntry(origin, motion rl.Vector3, box rl.BoundingBox) (float32, rl.Vector3, bool) {
+	o := [3]float32{origin.X, origin.Y, origin.Z}
+	d := [3]float32{motion.X, motion.Y, motion.Z}
+	lo := [3]float32{box.Min.X, box.Min.Y, box.Min.Z}
+	hi := [3]float32{box.Max.X, box.Max.Y, box.Max.Z}
+
+	tEnter := float32(math.Inf(-1))
+	tExit := float32(math.Inf(1))
+	enterAxis := -1
+	for axis := 0; axis < 3; axis++ {
+		if d[axis] == 0 {
+			// Moving parallel to this slab: must already be within it
+			if o[axis] <= lo[axis] || o[axis] >= hi[axis] {
+				return 0, rl.Vector3{}, false
+			}
+			continue
+		}
+		t1 := (lo[axis] - o[axis]) / d[axis]
+		t2 := (hi[axis] - o[axis]) / d[axis]
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		if t1 > tEnter {
+			tEnter = t1
+			enterAxis = axis
+		}
+		if t2 < tExit {
+			tExit = t2
+		}
+	}
+
+	// Ignore boxes we are already leaving, or that are beyond this move
+	if enterAxis < 0 || tEnter > tExit || tEnter > 1 || tExit <= 0 || tEnter < -COLLISION_SKIN {
+		return 0, rl.Vector3{}, false
+	}
+
+	normal := [3]float32{}
+	if d[enterAxis] > 0 {
+		normal[enterAxis] = -1
+	} else {
+		normal[enterAxis] = 1
+	}
+	return float32(math.Max(float64(tEnter), 0)), rl.Vector3{X: normal[0], Y: normal[1], Z: normal[2]}, true
+}
+
+// depenetrate pushes a box out of any obstacles it overlaps along the
+// axis of least penetration
+func depenetrate(center, half rl.Vector3, obstacles []rl.BoundingBox) rl.Vector3 {
+	for _, obstacle := range obstacles {
+		boxMin := rl.Vector3Subtract(center, half)
+		boxMax := rl.Vector3Add(center, half)
+		if boxMax.X <= obstacle.Min.X || boxMin.X >= obstacle.Max.X ||
+			boxMax.Y <= obstacle.Min.Y || boxMin.Y >= obstacle.Max.Y ||
+			boxMax.Z <= obstacle.Min.Z || boxMin.Z >= obstacle.Max.Z {
+			continue
+		}
+
+		// Candidate pushes along each axis in both directions
+		pushes := []rl.Vector3{
+			{X: obstacle.Max.X - boxMin.X + COLLISION_SKIN},
+			{X: obstacle.Min.X - boxMax.X - COLLISION_SKIN},
+			{Y: obstacle.Max.Y - boxMin.Y + COLLISION_SKIN},
+			{Y: obstacle.Min.Y - boxMax.Y - COLLISION_SKIN},
+			{Z: obstacle.Max.Z - boxMin.Z + COLLISION_SKIN},
+			{Z: obstacle.Min.Z - boxMax.Z - COLLISION_SKIN},
+		}
+		best := pushes[0]
+		for _, push := range pushes[1:] {
+			if rl.Vector3Length(push) < rl.Vector3Length(best) {
+				best = push
+			}
+		}
+		center = rl.Vector3Add(center, best)
+	}
+	return center
+}
+
+// sweptBounds returns the region covered by a box moving along motion,
+// padded to cover the skin and depenetration pushes
+func sweptBounds(box rl.BoundingBox, motion rl.Vector3) rl.BoundingBox {
+	moved := rl.BoundingBox{Min: rl.Vector3Add(box.Min, motion), Max: rl.Vector3Add(box.Max, motion)}
+	padding := rl.Vector3Scale(rl.Vector3Subtract(box.Max, box.Min), 0.5)
+	return rl.BoundingBox{
+		Min: rl.Vector3Subtract(rl.Vector3Min(box.Min, moved.Min), padding),
+		Max: rl.Vector3Add(rl.Vector3Max(box.Max, moved.Max), padding),
+	}
+}
+
+// OverlapsAny reports whether the box intersects any collider in the world
+func OverlapsAny(box rl.BoundingBox, world Broadphase) bool {
+	for _, obstacle := range world.QueryBox(box) {
+		if box.Max.X > obstacle.Min.X && box.Min.X < obstacle.Max.X &&
+			box.Max.Y > obstacle.Min.Y && box.Min.Y < obstacle.Max.Y &&
+			box.Max.Z > obstacle.Min.Z && box.Min.Z < obstacle.Max.Z {
+			return true
+		}
+	}
+	return false
+}
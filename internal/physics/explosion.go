@@ -0,0 +1,68 @@
+package physics
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Constants for explosions
+const (
+	LOS_EPSILON = 0.01 // Hits this close to the target do not block line of sight
+)
+
+// Explosion is an instantaneous blast that damages and pushes everything
+// in range that it can see
+type Explosion struct {
+	Center  rl.Vector3
+	Radius  float32
+	Damage  float32 // Damage at the center, falling off to zero at Radius
+	Impulse float32 // Momentum at the center, falling off the same way
+}
+
+// Falloff returns the fraction of full effect at a distance from the center,
+// decreasing linearly from 1 at the center to 0 at the radius
+func (e Explosion) Falloff(distance float32) float32 {
+	if distance >= e.Radius {
+		return 0
+	}
+	return 1 - distance/e.Radius
+}
+
+// Reach returns the point of box nearest the center and the fraction of
+// full effect there. A zero fraction means the box is out of range.
+func (e Explosion) Reach(box rl.BoundingBox) (rl.Vector3, float32) {
+	nearest := rl.Vector3Clamp(e.Center, box.Min, box.Max)
+	return nearest, e.Falloff(rl.Vector3Distance(e.Center, nearest))
+}
+
+// ImpulseAt returns the push felt by an object whose nearest point is at
+// point, directed away from the center
+func (e Explosion) ImpulseAt(point, objectCenter rl.Vector3) rl.Vector3 {
+	direction := rl.Vector3Subtract(objectCenter, e.Center)
+	if rl.Vector3Length(direction) == 0 {
+		direction = rl.Vector3{Y: 1}
+	}
+	strength := e.Impulse * e.Falloff(rl.Vector3Distance(e.Center, point))
+	return rl.Vector3Scale(rl.Vector3Normalize(direction), strength)
+}
+
+// LineOfSight reports whether the segment from one point to another is
+// clear of solid geometry. Enemies never block, and neither does the cube
+// at ignoreCube (use -1 for none), so a target cube does not hide itself.
+func (s *Scene) LineOfSight(from, to rl.Vector3, ignoreCube int) bool {
+	offset := rl.Vector3Subtract(to, from)
+	length := rl.Vector3Length(offset)
+	if length <= LOS_EPSILON {
+		return true
+	}
+	direction := rl.Vector3Scale(offset, 1/length)
+	for _, hit := range s.Raycast(from, direction, length) {
+		if hit.Distance >= length-LOS_EPSILON {
+			break
+		}
+		if hit.Kind == HIT_ENEMY || (hit.Kind == HIT_CUBE && hit.Index == ignoreCube) {
+			continue
+		}
+		return false
+	}
+	return true
+}
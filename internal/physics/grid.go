@@ -0,0 +1,301 @@
+package physics
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Constants for the broadphase grid
+const (
+	DEFAULT_CELL_SIZE  = 2.0 // World units per grid cell
+	MAX_CELLS_PER_ITEM = 64  // Items spanning more cells are kept in an always-tested list
+)
+
+// Broadphase finds the colliders that may touch a region of space
+type Broadphase interface {
+	QueryBox(region rl.BoundingBox) []rl.BoundingBox
+}
+
+// BoxList is a broadphase that returns every box for every query.
+// Useful for small collider sets and as a baseline for benchmarks.
+type BoxList []rl.BoundingBox
+
+// QueryBox returns every box in the list
+func (l BoxList) QueryBox(region rl.BoundingBox) []rl.BoundingBox {
+	return l
+}
+
+// cellKey addresses one grid cell
+type cellKey struct {
+	X, Y, Z int32
+}
+
+// gridItem is a box stored in the grid along with the cells it occupies
+type gridItem struct {
+	Box      rl.BoundingBox
+	Min, Max cellKey
+	Active   bool
+	Large    bool
+	stamp    uint32
+}
+
+// Grid is a uniform spatial hash over axis-aligned boxes. Items are addressed
+// by the ID returned from Insert. Queries are not safe for concurrent use.
+type Grid struct {
+	CellSize float32
+	cells    map[cellKey][]int
+	items    []gridItem
+	large    []int
+	free     []int
+	stamp    uint32
+}
+
+// NewGrid creates an empty grid with the given cell size
+func NewGrid(cellSize float32) *Grid {
+	if cellSize <= 0 {
+		cellSize = DEFAULT_CELL_SIZE
+	}
+	return &Grid{
+		CellSize: cellSize,
+		cells:    make(map[cellKey][]int),
+	}
+}
+
+// Insert adds a box to the grid and returns its ID
+func (g *Grid) Insert(box rl.BoundingBox) int {
+	var id int
+	if n := len(g.free); n > 0 {
+		id = g.free[n-1]
+		g.free = g.free[:n-1]
+	} else {
+		id = len(g.items)
+		g.items = append(g.items, gridItem{})
+	}
+	g.link(id, box)
+	return id
+}
+
+// Update moves an item to a new box, relinking cells only when they change
+func (g *Grid) Update(id int, box rl.BoundingBox) {
+	item := &g.items[id]
+	lo, hi := g.cellRange(box)
+	if item.Active && lo == item.Min && hi == item.Max {
+		item.Box = box
+		return
+	}
+	g.unlink(id)
+	g.link(id, box)
+}
+
+// Remove deletes an item from the grid and frees its ID for reuse
+func (g *Grid) Remove(id int) {
+	if !g.items[id].Active {
+		return
+	}
+	g.unlink(id)
+	g.free = append(g.free, id)
+}
+
+// Box returns the current box of an item
+func (g *Grid) Box(id int) rl.BoundingBox {
+	return g.items[id].Box
+}
+
+// Len returns the number of items in the grid
+func (g *Grid) Len() int {
+	return len(g.items) - len(g.free)
+}
+
+// QueryBoxIDs returns the IDs of items whose boxes overlap region
+func (g *Grid) QueryBoxIDs(region rl.BoundingBox) []int {
+	stamp := g.nextStamp()
+	var ids []int
+	visit := func(id int) {
+		item := &g.items[id]
+		if item.stamp == stamp {
+			return
+		}
+		item.stamp = stamp
+		if boxesOverlap(item.Box, region) {
+			ids = append(ids, id)
+		}
+	}
+
+	for _, id := range g.large {
+		visit(id)
+	}
+	lo, hi := g.cellRange(region)
+	for x := lo.X; x <= hi.X; x++ {
+		for y := lo.Y; y <= hi.Y; y++ {
+			for z := lo.Z; z <= hi.Z; z++ {
+				for _, id := range g.cells[cellKey{x, y, z}] {
+					visit(id)
+				}
+			}
+		}
+	}
+	return ids
+}
+
+// QueryBox returns the boxes of items that overlap region
+func (g *Grid) QueryBox(region rl.BoundingBox) []rl.BoundingBox {
+	ids := g.QueryBoxIDs(region)
+	boxes := make([]rl.BoundingBox, len(ids))
+	for i, id := range ids {
+		boxes[i] = g.items[id].Box
+	}
+	return boxes
+}
+
+// QueryRayIDs returns the IDs of items in the cells the segment from origin
+// along direction for maxDistance passes through. Callers still need an exact
+// intersection test. Direction must be normalized.
+func (g *Grid) QueryRayIDs(origin, direction rl.Vector3, maxDistance float32) []int {
+	stamp := g.nextStamp()
+	var ids []int
+	visit := func(id int) {
+		item := &g.items[id]
+		if item.stamp != stamp {
+			item.stamp = stamp
+			ids = append(ids, id)
+		}
+	}
+
+	for _, id := range g.large {
+		visit(id)
+	}
+	if len(g.cells) == 0 {
+		return ids
+	}
+
+	// Walk the cells along the ray (Amanatides-Woo traversal)
+	o := [3]float32{origin.X, origin.Y, origin.Z}
+	d := [3]float32{direction.X, direction.Y, direction.Z}
+	var cell, step [3]int32
+	var tMax, tDelta [3]float32
+	for axis := 0; axis < 3; axis++ {
+		cell[axis] = g.cellCoord(o[axis])
+		switch {
+		case d[axis] > 0:
+			step[axis] = 1
+			tMax[axis] = (float32(cell[axis]+1)*g.CellSize - o[axis]) / d[axis]
+			tDelta[axis] = g.CellSize / d[axis]
+		case d[axis] < 0:
+			step[axis] = -1
+			tMax[axis] = (float32(cell[axis])*g.CellSize - o[axis]) / d[axis]
+			tDelta[axis] = -g.CellSize / d[axis]
+		default:
+			tMax[axis] = float32(math.Inf(1))
+			tDelta[axis] = float32(math.Inf(1))
+		}
+	}
+
+	for {
+		for _, id := range g.cells[cellKey{cell[0], cell[1], cell[2]}] {
+			visit(id)
+		}
+
+		axis := 0
+		if tMax[1] < tMax[axis] {
+			axis = 1
+		}
+		if tMax[2] < tMax[axis] {
+			axis = 2
+		}
+		if tMax[axis] > maxDistance {
+			return ids
+		}
+		cell[axis] += step[axis]
+		tMax[axis] += tDelta[axis]
+	}
+}
+
+// link records an item in every cell its box covers
+func (g *Grid) link(id int, box rl.BoundingBox) {
+	lo, hi := g.cellRange(box)
+	item := &g.items[id]
+	*item = gridItem{Box: box, Min: lo, Max: hi, Active: true, stamp: item.stamp}
+
+	count := int64(hi.X-lo.X+1) * int64(hi.Y-lo.Y+1) * int64(hi.Z-lo.Z+1)
+	if count > MAX_CELLS_PER_ITEM {
+		item.Large = true
+		g.large = append(g.large, id)
+		return
+	}
+	for x := lo.X; x <= hi.X; x++ {
+		for y := lo.Y; y <= hi.Y; y++ {
+			for z := lo.Z; z <= hi.Z; z++ {
+				key := cellKey{x, y, z}
+				g.cells[key] = append(g.cells[key], id)
+			}
+		}
+	}
+}
+
+// unlink removes an item from every cell it was recorded in
+func (g *Grid) unlink(id int) {
+	item := &g.items[id]
+	if !item.Active {
+		return
+	}
+	item.Active = false
+	if item.Large {
+		g.large = removeID(g.large, id)
+		return
+	}
+	for x := item.Min.X; x <= item.Max.X; x++ {
+		for y := item.Min.Y; y <= item.Max.Y; y++ {
+			for z := item.Min.Z; z <= item.Max.Z; z++ {
+				key := cellKey{x, y, z}
+				if ids := removeID(g.cells[key], id); len(ids) > 0 {
+					g.cells[key] = ids
+				} else {
+					delete(g.cells, key)
+				}
+			}
+		}
+	}
+}
+
+// cellRange returns the first and last cells covered by a box
+func (g *Grid) cellRange(box rl.BoundingBox) (cellKey, cellKey) {
+	return cellKey{g.cellCoord(box.Min.X), g.cellCoord(box.Min.Y), g.cellCoord(box.Min.Z)},
+		cellKey{g.cellCoord(box.Max.X), g.cellCoord(box.Max.Y), g.cellCoord(box.Max.Z)}
+}
+
+// cellCoord converts a world coordinate to a cell index
+func (g *Grid) cellCoord(v float32) int32 {
+	return int32(math.Floor(float64(v / g.CellSize)))
+}
+
+// nextStamp starts a new query so items found in several cells are reported once
+func (g *Grid) nextStamp() uint32 {
+	g.stamp++
+	if g.stamp == 0 {
+		// Wrapped around: clear old stamps so none collide with the new one
+		for i := range g.items {
+			g.items[i].stamp = 0
+		}
+		g.stamp = 1
+	}
+	return g.stamp
+}
+
+// removeID deletes the first occurrence of id from ids without preserving order
+func removeID(ids []int, id int) []int {
+	for i, v := range ids {
+		if v == id {
+			ids[i] = ids[len(ids)-1]
+			return ids[:len(ids)-1]
+		}
+	}
+	return ids
+}
+
+// boxesOverlap reports whether two boxes intersect or touch
+func boxesOverlap(a, b rl.BoundingBox) bool {
+	return a.Max.X >= b.Min.X && a.Min.X <= b.Max.X &&
+		a.Max.Y >= b.Min.Y && a.Min.Y <= b.Max.Y &&
+		a.Max.Z >= b.Min.Z && a.Min.Z <= b.Max.Z
+}
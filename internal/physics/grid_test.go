@@ -0,0 +1,240 @@
+package physics
+
+import (
+	"math/rand"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Benchmark scene layout
+const (
+	BENCH_SEED       = 1
+	BENCH_BOXES      = 500
+	BENCH_WORLD_SIZE = 200.0 // Width and depth of the generated arena
+	BENCH_RAY_RANGE  = 100.0
+	BENCH_QUERIES    = 256 // Distinct rays and boxes cycled through per benchmark
+)
+
+// benchWorld is a generated level with the same boxes behind a grid-backed
+// scene and a linear scan, plus queries to run against both
+type benchWorld struct {
+	boxes      []rl.BoundingBox
+	linear     BoxList
+	scene      *Scene
+	origins    []rl.Vector3
+	directions []rl.Vector3
+	movers     []rl.BoundingBox
+	motion     rl.Vector3
+}
+
+// newBenchWorld generates boxCount random boxes and BENCH_QUERIES rays and
+// moving boxes from a fixed seed
+func newBenchWorld(boxCount int) *benchWorld {
+	rng := rand.New(rand.NewSource(BENCH_SEED))
+	w := &benchWorld{
+		boxes:      make([]rl.BoundingBox, boxCount),
+		scene:      NewScene(),
+		origins:    make([]rl.Vector3, BENCH_QUERIES),
+		directions: make([]rl.Vector3, BENCH_QUERIES),
+		movers:     make([]rl.BoundingBox, BENCH_QUERIES),
+		motion:     rl.Vector3{X: 0.1, Y: -0.05, Z: 0.08},
+	}
+	for i := range w.boxes {
+		w.boxes[i] = randomBox(rng, 0.5, 3)
+		w.scene.AddStatic(w.boxes[i], HIT_CUBE, i)
+	}
+	w.linear = BoxList(w.boxes)
+	for i := 0; i < BENCH_QUERIES; i++ {
+		w.origins[i] = randomPoint(rng)
+		w.directions[i] = rl.Vector3Normalize(rl.Vector3{X: rng.Float32()*2 - 1, Y: rng.Float32() - 0.8, Z: rng.Float32()*2 - 1})
+		w.movers[i] = randomBox(rng, 0.8, 1.8)
+	}
+	return w
+}
+
+// randomPoint returns a point inside the benchmark arena near the ground
+func randomPoint(rng *rand.Rand) rl.Vector3 {
+	return rl.Vector3{
+		X: (rng.Float32() - 0.5) * BENCH_WORLD_SIZE,
+		Y: rng.Float32() * 4,
+		Z: (rng.Float32() - 0.5) * BENCH_WORLD_SIZE,
+	}
+}
+
+// randomBox returns a box with sides between minSize and maxSize at a random point
+func randomBox(rng *rand.Rand, minSize, maxSize float32) rl.BoundingBox {
+	center := randomPoint(rng)
+	half := rl.Vector3{
+		X: (minSize + rng.Float32()*(maxSize-minSize)) / 2,
+		Y: (minSize + rng.Float32()*(maxSize-minSize)) / 2,
+		Z: (minSize + rng.Float32()*(maxSize-minSize)) / 2,
+	}
+	return rl.BoundingBox{Min: rl.Vector3Subtract(center, half), Max: rl.Vector3Add(center, half)}
+}
+
+// linearRaycastNearest finds the nearest box hit by testing every box
+func linearRaycastNearest(boxes []rl.BoundingBox, origin, direction rl.Vector3, maxDistance float32) (int, float32) {
+	nearest, nearestDistance := -1, maxDistance
+	for i, box := range boxes {
+		if d, _, hit := RayBox(origin, direction, maxDistance, box); hit && d < nearestDistance {
+			nearest, nearestDistance = i, d
+		}
+	}
+	return nearest, nearestDistance
+}
+
+// TestGridQueryBoxMatchesBoxList checks that box queries on the grid find
+// exactly the boxes a brute-force scan finds, including boxes too large to
+// be stored per cell
+func TestGridQueryBoxMatchesBoxList(t *testing.T) {
+	w := newBenchWorld(BENCH_BOXES)
+	huge := rl.BoundingBox{Min: rl.Vector3{X: -60, Y: -1, Z: -60}, Max: rl.Vector3{X: 60, Y: 0, Z: 60}}
+	boxes := append(w.boxes, huge)
+
+	grid := NewGrid(DEFAULT_CELL_SIZE)
+	index := make(map[int]int, len(boxes))
+	for i, box := range boxes {
+		index[grid.Insert(box)] = i
+	}
+
+	for q, region := range w.movers {
+		// Grow the query so it overlaps several boxes
+		region.Min = rl.Vector3Subtract(region.Min, rl.Vector3{X: 4, Y: 1, Z: 4})
+		region.Max = rl.Vector3Add(region.Max, rl.Vector3{X: 4, Y: 1, Z: 4})
+
+		want := map[int]bool{}
+		for i, box := range BoxList(boxes).QueryBox(region) {
+			if boxesOverlap(box, region) {
+				want[i] = true
+			}
+		}
+		got := map[int]bool{}
+		for _, id := range grid.QueryBoxIDs(region) {
+			got[index[id]] = true
+		}
+		if len(got) != len(want) {
+			t.Fatalf("query %d: grid found %d boxes, linear scan found %d", q, len(got), len(want))
+		}
+		for i := range want {
+			if !got[i] {
+				t.Fatalf("query %d: grid missed box %d", q, i)
+			}
+		}
+	}
+}
+
+// TestGridRaycastMatchesBoxList checks that raycasts through the grid hit
+// the same boxes at the same distances as testing every box
+func TestGridRaycastMatchesBoxList(t *testing.T) {
+	w := newBenchWorld(BENCH_BOXES)
+	for q := range w.origins {
+		origin, direction := w.origins[q], w.directions[q]
+
+		wantHits := map[int]float32{}
+		for i, box := range w.boxes {
+			if d, _, hit := RayBox(origin, direction, BENCH_RAY_RANGE, box); hit {
+				wantHits[i] = d
+			}
+		}
+		hits := w.scene.Raycast(origin, direction, BENCH_RAY_RANGE)
+		if len(hits) != len(wantHits) {
+			t.Fatalf("ray %d: scene hit %d boxes, linear scan hit %d", q, len(hits), len(wantHits))
+		}
+		for _, hit := range hits {
+			if d, ok := wantHits[hit.Index]; !ok || d != hit.Distance {
+				t.Fatalf("ray %d: scene hit box %d at %v, linear scan at %v (hit %v)", q, hit.Index, hit.Distance, d, ok)
+			}
+		}
+
+		wantIndex, wantDistance := linearRaycastNearest(w.boxes, origin, direction, BENCH_RAY_RANGE)
+		nearest, ok := w.scene.RaycastNearest(origin, direction, BENCH_RAY_RANGE)
+		if ok != (wantIndex >= 0) || (ok && (nearest.Index != wantIndex || nearest.Distance != wantDistance)) {
+			t.Fatalf("ray %d: nearest hit %d at %v, linear scan %d at %v", q, nearest.Index, nearest.Distance, wantIndex, wantDistance)
+		}
+	}
+}
+
+// TestGridCollisionMatchesBoxList checks that overlap and move-and-slide
+// resolve the same against the grid-backed scene as against a linear scan
+func TestGridCollisionMatchesBoxList(t *testing.T) {
+	w := newBenchWorld(BENCH_BOXES)
+	for q, mover := range w.movers {
+		if got, want := OverlapsAny(mover, w.scene), OverlapsAny(mover, w.linear); got != want {
+			t.Fatalf("mover %d: grid overlap %v, linear scan %v", q, got, want)
+		}
+		got := MoveAndSlide(mover, rl.Vector3Scale(w.motion, 20), w.scene)
+		want := MoveAndSlide(mover, rl.Vector3Scale(w.motion, 20), w.linear)
+		if got != want {
+			t.Fatalf("mover %d: grid slide %+v, linear scan %+v", q, got, want)
+		}
+	}
+}
+
+func BenchmarkRaycastLinear(b *testing.B) {
+	w := newBenchWorld(BENCH_BOXES)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q := i % BENCH_QUERIES
+		linearRaycastNearest(w.boxes, w.origins[q], w.directions[q], BENCH_RAY_RANGE)
+	}
+}
+
+func BenchmarkRaycastGrid(b *testing.B) {
+	w := newBenchWorld(BENCH_BOXES)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q := i % BENCH_QUERIES
+		w.scene.RaycastNearest(w.origins[q], w.directions[q], BENCH_RAY_RANGE)
+	}
+}
+
+func BenchmarkOverlapLinear(b *testing.B) {
+	w := newBenchWorld(BENCH_BOXES)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		OverlapsAny(w.movers[i%BENCH_QUERIES], w.linear)
+	}
+}
+
+func BenchmarkOverlapGrid(b *testing.B) {
+	w := newBenchWorld(BENCH_BOXES)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		OverlapsAny(w.movers[i%BENCH_QUERIES], w.scene)
+	}
+}
+
+func BenchmarkMoveAndSlideLinear(b *testing.B) {
+	w := newBenchWorld(BENCH_BOXES)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MoveAndSlide(w.movers[i%BENCH_QUERIES], w.motion, w.linear)
+	}
+}
+
+func BenchmarkMoveAndSlideGrid(b *testing.B) {
+	w := newBenchWorld(BENCH_BOXES)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MoveAndSlide(w.movers[i%BENCH_QUERIES], w.motion, w.scene)
+	}
+}
+
+func BenchmarkGridUpdate(b *testing.B) {
+	w := newBenchWorld(BENCH_BOXES)
+	grid := NewGrid(DEFAULT_CELL_SIZE)
+	ids := make([]int, BENCH_QUERIES)
+	for q := range ids {
+		ids[q] = grid.Insert(w.movers[q])
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q := i % BENCH_QUERIES
+		offset := rl.Vector3Scale(w.motion, float32(i%100))
+		grid.Update(ids[q], rl.BoundingBox{
+			Min: rl.Vector3Add(w.movers[q].Min, offset),
+			Max: rl.Vector3Add(w.movers[q].Max, offset),
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package physics
+
+import (
+	"fps/internal/clock"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Constants for projectiles
+const (
+	MAX_PROJECTILES = 128
+)
+
+// Projectile is a simulated round with travel time and gravity drop
+type Projectile struct {
+	Position     rl.Vector3
+	PrevPosition rl.Vector3
+	Velocity     rl.Vector3
+	Gravity      float32 // Downward acceleration in units per second squared
+	Damage       float32
+	Impulse      float32 // Momentum delivered to rigid bodies on impact
+	BlastRadius  float32 // Explodes on impact when positive
+	Traveled     float32
+	Range        float32 // Distance after which the projectile expires
+}
+
+// ProjectileHit reports a projectile striking a collider during a step
+type ProjectileHit struct {
+	Projectile Projectile
+	Hit        Hit
+}
+
+// ProjectileManager steps all projectiles in flight
+type ProjectileManager struct {
+	Projectiles []Projectile
+}
+
+// NewProjectileManager creates a new projectile manager
+func NewProjectileManager() *ProjectileManager {
+	return &ProjectileManager{
+		Projectiles: make([]Projectile, 0, MAX_PROJECTILES),
+	}
+}
+
+// Spawn launches a projectile from its Position with its Velocity.
+// The oldest projectile is dropped when the manager is full.
+func (pm *ProjectileManager) Spawn(p Projectile) {
+	if len(pm.Projectiles) >= MAX_PROJECTILES {
+		pm.Projectiles = append(pm.Projectiles[:0], pm.Projectiles[1:]...)
+	}
+	p.PrevPosition = p.Position
+	pm.Projectiles = append(pm.Projectiles, p)
+}
+
+// Update advances every projectile by the clock's tick duration, sweeping
+// the path it travels against the scene so fast rounds cannot tunnel
+// through thin geometry. Returns the hits from this step; projectiles that
+// hit something, run out of range or leave the space inBounds accepts are
+// removed.
+func (pm *ProjectileManager) Update(c *clock.Clock, scene *Scene, inBounds func(rl.Vector3) bool) []ProjectileHit {
+	deltaTime := c.Delta
+	var hits []ProjectileHit
+	active := pm.Projectiles[:0]
+	for _, p := range pm.Projectiles {
+		p.PrevPosition = p.Position
+
+		// Integrate with constant acceleration so the path is exact per step
+		gravity := rl.Vector3{Y: -p.Gravity}
+		step := rl.Vector3Add(rl.Vector3Scale(p.Velocity, deltaTime), rl.Vector3Scale(gravity, 0.5*deltaTime*deltaTime))
+		p.Velocity = rl.Vector3Add(p.Velocity, rl.Vector3Scale(gravity, deltaTime))
+
+		length := rl.Vector3Length(step)
+		if length == 0 {
+			active = append(active, p)
+			continue
+		}
+		direction := rl.Vector3Scale(step, 1/length)
+		if hit, ok := scene.RaycastNearest(p.Position, direction, length); ok {
+			p.Position = hit.Point
+			hits = append(hits, ProjectileHit{Projectile: p, Hit: hit})
+			continue
+		}
+
+		p.Position = rl.Vector3Add(p.Position, step)
+		p.Traveled += length
+		if p.Traveled < p.Range && inBounds(p.Position) {
+			active = append(active, p)
+		}
+	}
+	pm.Projectiles = active
+	return hits
+}
+
+// GetInterpolatedPosition blends the projectile position between the last two ticks
+func (p *Projectile) GetInterpolatedPosition(alpha float32) rl.Vector3 {
+	return rl.Vector3Lerp(p.PrevPosition, p.Position, alpha)
+}
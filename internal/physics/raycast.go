@@ -0,0 +1,260 @@
+package physics
+
+import (
+	"math"
+	"sort"
+
+	"fps/internal/enemy"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// HitKind identifies what kind of collider a ray hit
+type HitKind int
+
+const (
+	HIT_STATIC HitKind = iota // Level geometry with no gameplay identity, such as the floor
+	HIT_CUBE                  // A level box; Index is its position in Scene.Cubes
+	HIT_ENEMY                 // An enemy; Enemy points at it
+)
+
+// Hit describes a single ray intersection
+type Hit struct {
+	Distance float32
+	Point    rl.Vector3
+	Normal   rl.Vector3 // Outward normal of the surface the ray entered
+	Kind     HitKind
+	Index    int // Index passed to AddStatic, or the enemy's slice index
+	Enemy    *enemy.Enemy
+	Region   enemy.HitRegion // Body part struck, for enemy hits
+	Headshot bool
+
+	// Where the ray leaves a box collider, for penetration
+	ExitDistance float32
+	ExitNormal   rl.Vector3
+}
+
+// ExitPoint returns the point at which the ray leaves the collider
+func (h Hit) ExitPoint(origin, direction rl.Vector3) rl.Vector3 {
+	return rl.Vector3Add(origin, rl.Vector3Scale(direction, h.ExitDistance))
+}
+
+// Thickness returns how much of the collider lies along the ray
+func (h Hit) Thickness() float32 {
+	return h.ExitDistance - h.Distance
+}
+
+// sceneProxy links a grid item back to the object it stands for
+type sceneProxy struct {
+	Kind  HitKind
+	Index int
+	Enemy *enemy.Enemy
+}
+
+// Scene holds every collider ray and overlap queries can hit. Level geometry
+// lives in a static grid built once at load; moving entities live in a
+// dynamic grid synced every tick.
+type Scene struct {
+	Static         *Grid
+	Dynamic        *Grid
+	staticProxies  []sceneProxy // Indexed by Static grid ID
+	dynamicProxies []sceneProxy // Indexed by Dynamic grid ID
+	enemyIDs       map[*enemy.Enemy]int
+	bodyIDs        map[*RigidBody]int
+}
+
+// NewScene creates an empty scene
+func NewScene() *Scene {
+	return &Scene{
+		Static:   NewGrid(DEFAULT_CELL_SIZE),
+		Dynamic:  NewGrid(DEFAULT_CELL_SIZE),
+		enemyIDs: make(map[*enemy.Enemy]int),
+		bodyIDs:  make(map[*RigidBody]int),
+	}
+}
+
+// AddStatic adds a piece of level geometry. Index is reported back on hits.
+func (s *Scene) AddStatic(box rl.BoundingBox, kind HitKind, index int) {
+	id := s.Static.Insert(box)
+	s.staticProxies = setProxy(s.staticProxies, id, sceneProxy{Kind: kind, Index: index})
+}
+
+// SyncEnemies updates the dynamic grid to match the current enemy list.
+// Dead enemies are dropped so they no longer block shots.
+func (s *Scene) SyncEnemies(enemies []*enemy.Enemy) {
+	current := make(map[*enemy.Enemy]int, len(enemies))
+	for i, e := range enemies {
+		if !e.IsAlive() {
+			continue
+		}
+		box := e.GetBoundingBox()
+		id, ok := s.enemyIDs[e]
+		if ok {
+			s.Dynamic.Update(id, box)
+		} else {
+			id = s.Dynamic.Insert(box)
+		}
+		s.dynamicProxies = setProxy(s.dynamicProxies, id, sceneProxy{Kind: HIT_ENEMY, Index: i, Enemy: e})
+		current[e] = id
+	}
+	for e, id := range s.enemyIDs {
+		if _, ok := current[e]; !ok {
+			s.Dynamic.Remove(id)
+		}
+	}
+	s.enemyIDs = current
+}
+
+// SyncBodies updates the dynamic grid with the current rigid body boxes,
+// which are reported as cube hits
+func (s *Scene) SyncBodies(bodies []*RigidBody) {
+	for _, b := range bodies {
+		box := b.GetBoundingBox()
+		id, ok := s.bodyIDs[b]
+		if ok {
+			s.Dynamic.Update(id, box)
+		} else {
+			id = s.Dynamic.Insert(box)
+			s.bodyIDs[b] = id
+		}
+		s.dynamicProxies = setProxy(s.dynamicProxies, id, sceneProxy{Kind: HIT_CUBE, Index: b.Index})
+	}
+}
+
+// QueryBox returns the solid geometry overlapping region, static and dynamic,
+// so a scene can be used directly as the broadphase for character collision.
+// Enemies are not solid.
+func (s *Scene) QueryBox(region rl.BoundingBox) []rl.BoundingBox {
+	boxes := s.Static.QueryBox(region)
+	for _, id := range s.Dynamic.QueryBoxIDs(region) {
+		if s.dynamicProxies[id].Kind != HIT_ENEMY {
+			boxes = append(boxes, s.Dynamic.Box(id))
+		}
+	}
+	return boxes
+}
+
+// Raycast returns every collider hit by the ray within maxDistance, nearest
+// first. Direction must be normalized.
+func (s *Scene) Raycast(origin, direction rl.Vector3, maxDistance float32) []Hit {
+	var hits []Hit
+	collect := func(grid *Grid, proxies []sceneProxy) {
+		for _, id := range grid.QueryRayIDs(origin, direction, maxDistance) {
+			proxy := proxies[id]
+			if proxy.Enemy != nil && !proxy.Enemy.IsAlive() {
+				continue // Killed since the last sync
+			}
+			distance, normal, hit := RayBox(origin, direction, maxDistance, grid.Box(id))
+			if !hit && (proxy.Enemy == nil || !pointInBox(origin, grid.Box(id))) {
+				continue // Shots fired from inside an enemy's bounds still test its hitboxes
+			}
+			hitResult := Hit{
+				Distance: distance,
+				Normal:   normal,
+				Kind:     proxy.Kind,
+				Index:    proxy.Index,
+				Enemy:    proxy.Enemy,
+			}
+			hitResult.ExitDistance, hitResult.ExitNormal = rayBoxExit(origin, direction, grid.Box(id))
+			if proxy.Enemy != nil {
+				// The bounding box only bounds the body; the hitboxes decide
+				if hitResult, hit = rayEnemy(origin, direction, maxDistance, hitResult); !hit {
+					continue
+				}
+			}
+			hitResult.Point = rl.Vector3Add(origin, rl.Vector3Scale(direction, hitResult.Distance))
+			hits = append(hits, hitResult)
+		}
+	}
+	collect(s.Static, s.staticProxies)
+	collect(s.Dynamic, s.dynamicProxies)
+
+	sort.SliceStable(hits, func(a, b int) bool {
+		return hits[a].Distance < hits[b].Distance
+	})
+	return hits
+}
+
+// RaycastNearest returns the closest hit along the ray, if any
+func (s *Scene) RaycastNearest(origin, direction rl.Vector3, maxDistance float32) (Hit, bool) {
+	hits := s.Raycast(origin, direction, maxDistance)
+	if len(hits) == 0 {
+		return Hit{}, false
+	}
+	return hits[0], true
+}
+
+// RayBox intersects a ray with a box and returns the entry distance and face
+// normal. Direction must be normalized.
+func RayBox(origin, direction rl.Vector3, maxDistance float32, box rl.BoundingBox) (float32, rl.Vector3, bool) {
+	t, normal, hit := segmentBoxEntry(origin, rl.Vector3Scale(direction, maxDistance), box)
+	if !hit {
+		return 0, rl.Vector3{}, false
+	}
+	return t * maxDistance, normal, true
+}
+
+// pointInBox reports whether p lies inside or on the surface of box
+func pointInBox(p rl.Vector3, box rl.BoundingBox) bool {
+	return p.X >= box.Min.X && p.X <= box.Max.X &&
+		p.Y >= box.Min.Y && p.Y <= box.Max.Y &&
+		p.Z >= box.Min.Z && p.Z <= box.Max.Z
+}
+
+// rayBoxExit returns the distance at which a ray leaves a box it has
+// entered and the outward normal of the exit face
+func rayBoxExit(origin, direction rl.Vector3, box rl.BoundingBox) (float32, rl.Vector3) {
+	o := [3]float32{origin.X, origin.Y, origin.Z}
+	d := [3]float32{direction.X, direction.Y, direction.Z}
+	lo := [3]float32{box.Min.X, box.Min.Y, box.Min.Z}
+	hi := [3]float32{box.Max.X, box.Max.Y, box.Max.Z}
+
+	tExit := float32(math.Inf(1))
+	exitAxis := 0
+	for axis := 0; axis < 3; axis++ {
+		if d[axis] == 0 {
+			continue
+		}
+		t := (hi[axis] - o[axis]) / d[axis]
+		if d[axis] < 0 {
+			t = (lo[axis] - o[axis]) / d[axis]
+		}
+		if t < tExit {
+			tExit = t
+			exitAxis = axis
+		}
+	}
+
+	normal := [3]float32{}
+	if d[exitAxis] > 0 {
+		normal[exitAxis] = 1
+	} else {
+		normal[exitAxis] = -1
+	}
+	return tExit, rl.Vector3{X: normal[0], Y: normal[1], Z: normal[2]}
+}
+
+// rayEnemy refines a bounding box hit against the enemy's hitboxes and
+// returns the nearest one struck
+func rayEnemy(origin, direction rl.Vector3, maxDistance float32, hit Hit) (Hit, bool) {
+	found := false
+	for _, h := range hit.Enemy.GetWorldHitboxes() {
+		distance, normal, ok := RayHitbox(origin, direction, maxDistance, h)
+		if ok && (!found || distance < hit.Distance) {
+			found = true
+			hit.Distance = distance
+			hit.Normal = normal
+			hit.Region = h.Region
+			hit.Headshot = h.Region == enemy.REGION_HEAD
+		}
+	}
+	return hit, found
+}
+
+// setProxy stores a proxy at a grid ID, growing the slice as needed
+func setProxy(proxies []sceneProxy, id int, proxy sceneProxy) []sceneProxy {
+	for len(proxies) <= id {
+		proxies = append(proxies, sceneProxy{})
+	}
+	proxies[id] = proxy
+	return proxies
+}
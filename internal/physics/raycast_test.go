@@ -0,0 +1,64 @@
+package physics
+
+import (
+	"testing"
+
+	"fps/internal/enemy"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// TestRayShapesHitFromInside checks that rays starting inside a sphere or
+// capsule hit it at distance 0
+func TestRayShapesHitFromInside(t *testing.T) {
+	origin := rl.Vector3{X: 0.1, Y: 1}
+	direction := rl.Vector3{Z: 1}
+
+	if d, _, hit := RaySphere(origin, direction, 10, rl.Vector3{Y: 1}, 0.5); !hit || d != 0 {
+		t.Fatalf("sphere: hit %v at %v, want a hit at 0", hit, d)
+	}
+	if d, _, hit := RayCapsule(origin, direction, 10, rl.Vector3{}, rl.Vector3{Y: 2}, 0.5); !hit || d != 0 {
+		t.Fatalf("capsule: hit %v at %v, want a hit at 0", hit, d)
+	}
+	if _, _, hit := RaySphere(rl.Vector3{X: 2, Y: 1}, direction, 10, rl.Vector3{Y: 1}, 0.5); hit {
+		t.Fatal("sphere: ray passing beside the sphere hit it")
+	}
+}
+
+// TestRaycastHitsEnemyFromInsideBounds fires point-blank from inside an
+// enemy's bounding box and checks the hitboxes are still tested
+func TestRaycastHitsEnemyFromInsideBounds(t *testing.T) {
+	e := enemy.New(0, rl.Vector3{})
+	scene := NewScene()
+	scene.SyncEnemies([]*enemy.Enemy{e})
+
+	box := e.GetBoundingBox()
+	origin := rl.Vector3{X: box.Max.X - 0.05, Y: 1.2, Z: 0}
+	if !pointInBox(origin, box) {
+		t.Fatal("test origin is not inside the enemy bounds")
+	}
+	hit, ok := scene.RaycastNearest(origin, rl.Vector3{X: -1}, 10)
+	if !ok || hit.Kind != HIT_ENEMY || hit.Region != enemy.REGION_TORSO {
+		t.Fatalf("point-blank shot from inside the enemy bounds: hit %v %+v", ok, hit)
+	}
+}
+
+// TestRaycastReportsExit checks a box hit records where the ray leaves it,
+// which penetration uses to price the material crossed
+func TestRaycastReportsExit(t *testing.T) {
+	scene := NewScene()
+	scene.AddStatic(rl.BoundingBox{Min: rl.Vector3{X: 2, Y: -1, Z: -1}, Max: rl.Vector3{X: 2.5, Y: 1, Z: 1}}, HIT_CUBE, 0)
+
+	hit, ok := scene.RaycastNearest(rl.Vector3{}, rl.Vector3{X: 1}, 10)
+	if !ok {
+		t.Fatal("ray missed the box")
+	}
+	if hit.Distance != 2 || hit.ExitDistance != 2.5 || hit.Thickness() != 0.5 {
+		t.Fatalf("entered at %v and left at %v, want 2 and 2.5", hit.Distance, hit.ExitDistance)
+	}
+	if hit.ExitNormal != (rl.Vector3{X: 1}) {
+		t.Fatalf("exit normal %v, want +X", hit.ExitNormal)
+	}
+	if exit := hit.ExitPoint(rl.Vector3{}, rl.Vector3{X: 1}); exit != (rl.Vector3{X: 2.5}) {
+		t.Fatalf("exit point %v, want (2.5, 0, 0)", exit)
+	}
+}
@@ -0,0 +1,230 @@
+package physics
+
+import (
+	"math"
+	"sort"
+
+	"fps/internal/clock"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Constants for rigid bodies
+const (
+	BODY_GRAVITY      = 20.0 // Matches the player's fall so the world feels consistent
+	BODY_RESTITUTION  = 0.1  // Fraction of approach speed kept after a bounce
+	BODY_FRICTION     = 0.5  // Coulomb friction coefficient between contacts
+	SOLVER_ITERATIONS = 8    // Contact passes per tick; more passes settle stacks faster
+	MAX_BODY_SPEED    = 50.0 // Clamp to keep large impulses from tunneling
+	PUSH_REACH        = 0.02 // How far past its sides a pusher touches bodies, so ones it is blocked against still move
+)
+
+// RigidBody is a box with mass that falls, collides and can be pushed.
+// Bodies stay axis aligned: they slide and stack but do not tumble.
+type RigidBody struct {
+	Index        int // Cube index this body drives
+	Position     rl.Vector3
+	PrevPosition rl.Vector3
+	Velocity     rl.Vector3
+	HalfExtents  rl.Vector3
+	Mass         float32
+}
+
+// NewRigidBody creates a resting body centered at position
+func NewRigidBody(index int, position, size rl.Vector3, mass float32) *RigidBody {
+	return &RigidBody{
+		Index:        index,
+		Position:     position,
+		PrevPosition: position,
+		HalfExtents:  rl.Vector3Scale(size, 0.5),
+		Mass:         mass,
+	}
+}
+
+// InverseMass returns 1/mass, or zero for immovable bodies
+func (b *RigidBody) InverseMass() float32 {
+	if b.Mass <= 0 {
+		return 0
+	}
+	return 1 / b.Mass
+}
+
+// ApplyImpulse changes the body's velocity by impulse/mass
+func (b *RigidBody) ApplyImpulse(impulse rl.Vector3) {
+	b.Velocity = rl.Vector3Add(b.Velocity, rl.Vector3Scale(impulse, b.InverseMass()))
+}
+
+// GetBoundingBox returns the body's current box
+func (b *RigidBody) GetBoundingBox() rl.BoundingBox {
+	return rl.BoundingBox{
+		Min: rl.Vector3Subtract(b.Position, b.HalfExtents),
+		Max: rl.Vector3Add(b.Position, b.HalfExtents),
+	}
+}
+
+// SavePreviousState records the current position for render interpolation
+func (b *RigidBody) SavePreviousState() {
+	b.PrevPosition = b.Position
+}
+
+// GetInterpolatedPosition blends the previous and current tick positions
+func (b *RigidBody) GetInterpolatedPosition(alpha float32) rl.Vector3 {
+	return rl.Vector3Lerp(b.PrevPosition, b.Position, alpha)
+}
+
+// Pusher is a kinematic box, such as the player, that bodies collide with
+// but cannot move. Bodies it walks into are carried along at its Velocity.
+type Pusher struct {
+	Box      rl.BoundingBox
+	Velocity rl.Vector3
+}
+
+// BodyManager simulates every rigid body against static geometry and each other
+type BodyManager struct {
+	Bodies  []*RigidBody
+	Gravity float32
+}
+
+// NewBodyManager creates an empty body manager
+func NewBodyManager() *BodyManager {
+	return &BodyManager{Gravity: BODY_GRAVITY}
+}
+
+// Add registers a body with the simulation
+func (bm *BodyManager) Add(b *RigidBody) {
+	bm.Bodies = append(bm.Bodies, b)
+}
+
+// SavePreviousState snapshots every body for interpolation
+func (bm *BodyManager) SavePreviousState() {
+	for _, b := range bm.Bodies {
+		b.SavePreviousState()
+	}
+}
+
+// Update integrates gravity and velocity for one tick, then resolves
+// contacts against the static world, the pushers and between bodies
+func (bm *BodyManager) Update(c *clock.Clock, world Broadphase, pushers ...Pusher) {
+	deltaTime := c.Delta
+	if deltaTime <= 0 {
+		return
+	}
+	for _, b := range bm.Bodies {
+		b.Velocity.Y -= bm.Gravity * deltaTime
+		if speed := rl.Vector3Length(b.Velocity); speed > MAX_BODY_SPEED {
+			b.Velocity = rl.Vector3Scale(b.Velocity, MAX_BODY_SPEED/speed)
+		}
+		b.Position = rl.Vector3Add(b.Position, rl.Vector3Scale(b.Velocity, deltaTime))
+	}
+
+	// Resolving from the bottom up lets supports settle before what rests on them
+	order := make([]*RigidBody, len(bm.Bodies))
+	copy(order, bm.Bodies)
+	sort.SliceStable(order, func(i, j int) bool {
+		return order[i].Position.Y < order[j].Position.Y
+	})
+
+	// A massless body stands in for each pusher so it is immovable but
+	// still hands its velocity to what it touches
+	kinematic := make([]*RigidBody, len(pushers))
+	reach := make([]rl.BoundingBox, len(pushers))
+	for i, p := range pushers {
+		kinematic[i] = &RigidBody{Velocity: p.Velocity}
+		reach[i] = p.Box
+		reach[i].Min.X -= PUSH_REACH
+		reach[i].Min.Z -= PUSH_REACH
+		reach[i].Max.X += PUSH_REACH
+		reach[i].Max.Z += PUSH_REACH
+	}
+
+	for iteration := 0; iteration < SOLVER_ITERATIONS; iteration++ {
+		for i, a := range order {
+			for _, obstacle := range world.QueryBox(a.GetBoundingBox()) {
+				resolveContact(a, nil, obstacle)
+			}
+			for j, pusher := range kinematic {
+				resolveContact(a, pusher, reach[j])
+			}
+			// Dynamic cubes are few, so body pairs are tested directly
+			for _, b := range order[i+1:] {
+				resolveContact(a, b, b.GetBoundingBox())
+			}
+		}
+	}
+}
+
+// resolveContact separates body a from box (owned by body b, or static when
+// b is nil) along the axis of least penetration and removes approaching
+// velocity with restitution and friction
+func resolveContact(a, b *RigidBody, box rl.BoundingBox) {
+	normal, depth, ok := boxPenetration(a.GetBoundingBox(), box)
+	if !ok {
+		return
+	}
+
+	invA := a.InverseMass()
+	invB := float32(0)
+	if b != nil {
+		invB = b.InverseMass()
+	}
+	invSum := invA + invB
+	if invSum == 0 {
+		return
+	}
+
+	// Push the pair apart in proportion to their inverse masses
+	a.Position = rl.Vector3Add(a.Position, rl.Vector3Scale(normal, depth*invA/invSum))
+	if b != nil {
+		b.Position = rl.Vector3Subtract(b.Position, rl.Vector3Scale(normal, depth*invB/invSum))
+	}
+
+	relative := a.Velocity
+	if b != nil {
+		relative = rl.Vector3Subtract(a.Velocity, b.Velocity)
+	}
+	approach := rl.Vector3DotProduct(relative, normal)
+	if approach >= 0 {
+		return
+	}
+
+	// Normal impulse stops the approach, keeping a little bounce
+	j := -(1 + BODY_RESTITUTION) * approach / invSum
+	impulse := rl.Vector3Scale(normal, j)
+
+	// Friction opposes sliding, limited by the normal impulse
+	tangent := rl.Vector3Subtract(relative, rl.Vector3Scale(normal, approach))
+	if slide := rl.Vector3Length(tangent); slide > 0 {
+		jt := float32(math.Min(float64(slide/invSum), float64(BODY_FRICTION*j)))
+		impulse = rl.Vector3Subtract(impulse, rl.Vector3Scale(tangent, jt/slide))
+	}
+
+	a.Velocity = rl.Vector3Add(a.Velocity, rl.Vector3Scale(impulse, invA))
+	if b != nil {
+		b.Velocity = rl.Vector3Subtract(b.Velocity, rl.Vector3Scale(impulse, invB))
+	}
+}
+
+// boxPenetration returns the direction to push box a out of box b and how far,
+// choosing the axis with the smallest overlap
+func boxPenetration(a, b rl.BoundingBox) (rl.Vector3, float32, bool) {
+	overlaps := [3][2]float32{
+		{b.Max.X - a.Min.X, a.Max.X - b.Min.X},
+		{b.Max.Y - a.Min.Y, a.Max.Y - b.Min.Y},
+		{b.Max.Z - a.Min.Z, a.Max.Z - b.Min.Z},
+	}
+	bestDepth := float32(math.Inf(1))
+	var best [3]float32
+	for axis, overlap := range overlaps {
+		if overlap[0] <= 0 || overlap[1] <= 0 {
+			return rl.Vector3{}, 0, false
+		}
+		// overlap[0] pushes a toward +axis, overlap[1] toward -axis
+		for side, depth := range overlap {
+			if depth < bestDepth {
+				bestDepth = depth
+				best = [3]float32{}
+				best[axis] = 1 - 2*float32(side)
+			}
+		}
+	}
+	return rl.Vector3{X: best[0], Y: best[1], Z: best[2]}, bestDepth, true
+}
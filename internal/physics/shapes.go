@@ -0,0 +1,101 @@
+package physics
+
+import (
+	"math"
+
+	"fps/internal/enemy"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// RaySphere intersects a ray with a sphere and returns the entry distance and
+// surface normal. Rays starting inside the sphere hit it at distance 0.
+func RaySphere(origin, direction rl.Vector3, maxDistance float32, center rl.Vector3, radius float32) (float32, rl.Vector3, bool) {
+	m := rl.Vector3Subtract(origin, center)
+	b := rl.Vector3DotProduct(m, direction)
+	c := rl.Vector3DotProduct(m, m) - radius*radius
+	if c <= 0 {
+		return 0, rl.Vector3Negate(direction), true
+	}
+	if b > 0 {
+		return 0, rl.Vector3{}, false
+	}
+	disc := b*b - c
+	if disc < 0 {
+		return 0, rl.Vector3{}, false
+	}
+	t := -b - float32(math.Sqrt(float64(disc)))
+	if t > maxDistance {
+		return 0, rl.Vector3{}, false
+	}
+	point := rl.Vector3Add(origin, rl.Vector3Scale(direction, t))
+	return t, rl.Vector3Normalize(rl.Vector3Subtract(point, center)), true
+}
+
+// RayCapsule intersects a ray with a capsule (the segment start-end swept by
+// radius) and returns the entry distance and surface normal. Rays starting
+// inside the capsule hit it at distance 0.
+func RayCapsule(origin, direction rl.Vector3, maxDistance float32, start, end rl.Vector3, radius float32) (float32, rl.Vector3, bool) {
+	bestT := float32(math.Inf(1))
+	var bestNormal rl.Vector3
+
+	axis := rl.Vector3Subtract(end, start)
+	offset := rl.Vector3Subtract(origin, start)
+	axisLenSq := rl.Vector3DotProduct(axis, axis)
+
+	// Inside: the origin is within radius of the nearest point on the segment
+	core := start
+	if axisLenSq > 0 {
+		core = rl.Vector3Add(start, rl.Vector3Scale(axis, rl.Clamp(rl.Vector3DotProduct(offset, axis)/axisLenSq, 0, 1)))
+	}
+	if rl.Vector3DistanceSqr(origin, core) <= radius*radius {
+		return 0, rl.Vector3Negate(direction), true
+	}
+
+	// Cylinder body: solve |(p - start) x axis|^2 = radius^2 * |axis|^2
+	axisDir := rl.Vector3DotProduct(axis, direction)
+	axisOffset := rl.Vector3DotProduct(axis, offset)
+	a := axisLenSq - axisDir*axisDir
+	b := axisLenSq*rl.Vector3DotProduct(offset, direction) - axisOffset*axisDir
+	c := axisLenSq*rl.Vector3DotProduct(offset, offset) - axisOffset*axisOffset - radius*radius*axisLenSq
+	if a > 1e-6 && c > 0 {
+		if disc := b*b - a*c; disc >= 0 {
+			t := (-b - float32(math.Sqrt(float64(disc)))) / a
+			along := axisOffset + t*axisDir
+			if t >= 0 && t <= maxDistance && along > 0 && along < axisLenSq {
+				point := rl.Vector3Add(origin, rl.Vector3Scale(direction, t))
+				core := rl.Vector3Add(start, rl.Vector3Scale(axis, along/axisLenSq))
+				bestT = t
+				bestNormal = rl.Vector3Normalize(rl.Vector3Subtract(point, core))
+			}
+		}
+	}
+
+	// Rounded caps
+	for _, center := range []rl.Vector3{start, end} {
+		if t, normal, hit := RaySphere(origin, direction, maxDistance, center, radius); hit && t < bestT {
+			bestT = t
+			bestNormal = normal
+		}
+	}
+
+	if math.IsInf(float64(bestT), 1) {
+		return 0, rl.Vector3{}, false
+	}
+	return bestT, bestNormal, true
+}
+
+// RayHitbox intersects a ray with a world-space hitbox of any shape
+func RayHitbox(origin, direction rl.Vector3, maxDistance float32, h enemy.Hitbox) (float32, rl.Vector3, bool) {
+	switch h.Shape {
+	case enemy.SHAPE_SPHERE:
+		return RaySphere(origin, direction, maxDistance, h.Start, h.Radius)
+	case enemy.SHAPE_CAPSULE:
+		return RayCapsule(origin, direction, maxDistance, h.Start, h.End, h.Radius)
+	default:
+		box := rl.BoundingBox{Min: h.Start, Max: h.End}
+		if pointInBox(origin, box) {
+			return 0, rl.Vector3Negate(direction), true
+		}
+		return RayBox(origin, direction, maxDistance, box)
+	}
+}
@@ -0,0 +1,212 @@
+package player
+
+import (
+	"math"
+
+	"fps/internal/physics"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Constants for stance changes
+const (
+	EYE_HEIGHT_SPEED = 6.0 // Eye height change per second when changing stance
+)
+
+// MovementParams tunes how the player accelerates, stops and jumps. The model
+// follows classic arena shooters: ground friction, acceleration toward a wish
+// velocity, and weak air acceleration capped at AirSpeedCap that still allows
+// strafe-turning in the air.
+type MovementParams struct {
+	MaxSpeed          float32 // Ground wish speed with full input (units/s)
+	GroundAccel       float32 // Fraction of wish speed gained per second on the ground
+	AirAccel          float32 // Fraction of wish speed gained per second in the air
+	AirSpeedCap       float32 // Largest speed air acceleration can add along the wish direction
+	Friction          float32 // Ground deceleration rate
+	StopSpeed         float32 // Friction acts as if moving at least this fast, so stops are crisp
+	CrouchSpeedFactor float32 // Wish speed multiplier while crouched on the ground
+	SprintSpeedFactor float32 // Wish speed multiplier while sprinting
+	JumpSpeed         float32
+	Gravity           float32
+
+	MaxStamina        float32
+	SprintDrain       float32 // Stamina spent per second of sprinting
+	StaminaRegen      float32 // Stamina recovered per second when not sprinting
+	StaminaRegenDelay float32 // Seconds after sprinting before stamina recovers
+	MinSprintStamina  float32 // Stamina needed to sprint again after running dry
+}
+
+// MoveInput is the movement intent for a single tick
+type MoveInput struct {
+	WishDir rl.Vector3 // Desired direction on the ground plane, length at most 1
+	Jump    bool
+	Crouch  bool
+	Sprint  bool
+}
+
+// DefaultMovementParams returns the standard movement feel
+func DefaultMovementParams() MovementParams {
+	return MovementParams{
+		MaxSpeed:          5.0,
+		GroundAccel:       10.0,
+		AirAccel:          10.0,
+		AirSpeedCap:       0.5,
+		Friction:          6.0,
+		StopSpeed:         1.5,
+		CrouchSpeedFactor: 0.5,
+		SprintSpeedFactor: 1.6,
+		JumpSpeed:         7.0, // Clears a 1 unit cube with room to spare
+		Gravity:           20.0,
+
+		MaxStamina:        100.0,
+		SprintDrain:       25.0,
+		StaminaRegen:      20.0,
+		StaminaRegenDelay: 1.0,
+		MinSprintStamina:  20.0,
+	}
+}
+
+// Move advances the player by one tick: stance changes, sprinting, friction
+// and acceleration, jumping, gravity and collision against the world
+func (p *Player) Move(move MoveInput, world physics.Broadphase, deltaTime float32) {
+	params := p.Movement
+	p.updateStance(move.Crouch, world)
+	p.updateStamina(move.Sprint, deltaTime)
+
+	// Split the wish into a unit direction and a speed
+	wishDir := move.WishDir
+	wishDir.Y = 0
+	wishAmount := rl.Vector3Length(wishDir)
+	if wishAmount > 1 {
+		wishDir = rl.Vector3Scale(wishDir, 1/wishAmount)
+		wishAmount = 1
+	}
+	if wishAmount > 0 {
+		wishDir = rl.Vector3Scale(wishDir, 1/wishAmount)
+	}
+	wishSpeed := params.MaxSpeed * wishAmount
+	if p.Sprinting {
+		wishSpeed *= params.SprintSpeedFactor
+	}
+
+	if p.Grounded && move.Jump {
+		// Jumping skips friction for this tick so timed jumps keep their speed
+		p.Velocity.Y = params.JumpSpeed
+		p.Grounded = false
+	}
+
+	if p.Grounded {
+		if p.Crouching {
+			wishSpeed *= params.CrouchSpeedFactor
+		}
+		p.applyFriction(params, deltaTime)
+		p.accelerate(wishDir, wishSpeed, wishSpeed, params.GroundAccel, deltaTime)
+	} else {
+		p.accelerate(wishDir, wishSpeed, min(wishSpeed, params.AirSpeedCap), params.AirAccel, deltaTime)
+	}
+	p.Velocity.Y -= params.Gravity * deltaTime
+
+	p.PushVelocity = rl.Vector3{X: p.Velocity.X, Z: p.Velocity.Z}
+	result := p.MoveAndSlide(rl.Vector3Scale(p.Velocity, deltaTime), world)
+
+	// Walls absorb the part of the velocity that pushed into them
+	if result.HitWall {
+		p.Velocity.X = result.Motion.X / deltaTime
+		p.Velocity.Z = result.Motion.Z / deltaTime
+	}
+
+	// Landing stops the fall; bumping a ceiling ends the ascent
+	p.Grounded = result.HitFloor && p.Velocity.Y <= 0
+	if result.HitFloor && p.Velocity.Y < 0 {
+		p.Velocity.Y = 0
+	}
+	if result.HitCeiling && p.Velocity.Y > 0 {
+		p.Velocity.Y = 0
+	}
+
+	p.updateEyeHeight(deltaTime)
+}
+
+// GetHorizontalSpeed returns the player's speed on the ground plane
+func (p *Player) GetHorizontalSpeed() float32 {
+	return float32(math.Hypot(float64(p.Velocity.X), float64(p.Velocity.Z)))
+}
+
+// applyFriction slows horizontal velocity while on the ground
+func (p *Player) applyFriction(params MovementParams, deltaTime float32) {
+	speed := p.GetHorizontalSpeed()
+	if speed < 0.0001 {
+		p.Velocity.X = 0
+		p.Velocity.Z = 0
+		return
+	}
+
+	control := max(speed, params.StopSpeed)
+	newSpeed := max(speed-control*params.Friction*deltaTime, 0)
+	scale := newSpeed / speed
+	p.Velocity.X *= scale
+	p.Velocity.Z *= scale
+}
+
+// accelerate adds velocity along wishDir until the speed in that direction
+// reaches speedCap. The gain per tick scales with wishSpeed, which is what
+// lets air strafing build speed while the cap keeps it gradual.
+func (p *Player) accelerate(wishDir rl.Vector3, wishSpeed, speedCap, accel, deltaTime float32) {
+	currentSpeed := p.Velocity.X*wishDir.X + p.Velocity.Z*wishDir.Z
+	addSpeed := speedCap - currentSpeed
+	if addSpeed <= 0 {
+		return
+	}
+
+	accelSpeed := min(accel*wishSpeed*deltaTime, addSpeed)
+	p.Velocity.X += wishDir.X * accelSpeed
+	p.Velocity.Z += wishDir.Z * accelSpeed
+}
+
+// updateStamina starts or stops sprinting and drains or regenerates stamina.
+// Running dry forces a rest until MinSprintStamina has recovered.
+func (p *Player) updateStamina(sprint bool, deltaTime float32) {
+	params := p.Movement
+	if p.Stamina <= 0 {
+		p.Exhausted = true
+	} else if p.Stamina >= params.MinSprintStamina {
+		p.Exhausted = false
+	}
+
+	p.Sprinting = sprint && !p.Crouching && !p.Exhausted
+	if p.Sprinting {
+		p.Stamina = max(p.Stamina-params.SprintDrain*deltaTime, 0)
+		p.StaminaRegenTimer = params.StaminaRegenDelay
+		return
+	}
+
+	if p.StaminaRegenTimer > 0 {
+		p.StaminaRegenTimer -= deltaTime
+		return
+	}
+	p.Stamina = min(p.Stamina+params.StaminaRegen*deltaTime, params.MaxStamina)
+}
+
+// updateStance crouches immediately but only stands up when there is room
+func (p *Player) updateStance(crouch bool, world physics.Broadphase) {
+	if crouch {
+		p.Crouching = true
+		return
+	}
+	if p.Crouching && !physics.OverlapsAny(GetCollisionBoxAt(p.Position, PLAYER_HEIGHT), world) {
+		p.Crouching = false
+	}
+}
+
+// updateEyeHeight eases the eye toward the height for the current stance
+func (p *Player) updateEyeHeight(deltaTime float32) {
+	target := float32(EYE_HEIGHT)
+	if p.Crouching {
+		target = CROUCH_EYE_HEIGHT
+	}
+	step := EYE_HEIGHT_SPEED * deltaTime
+	if p.EyeHeight < target {
+		p.EyeHeight = min(p.EyeHeight+step, target)
+	} else if p.EyeHeight > target {
+		p.EyeHeight = max(p.EyeHeight-step, target)
+	}
+}
@@ -3,39 +3,198 @@ package player
 import (
 	"math"
 
+	"fps/internal/physics"
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
 // Constants for player behavior
 const (
-	EYE_HEIGHT = 1.7
+	EYE_HEIGHT     = 1.7
+	HEAD_CLEARANCE = 0.1 // Space between the eyes and the top of the collision volume
+	PLAYER_RADIUS  = 0.4
+	PLAYER_HEIGHT  = EYE_HEIGHT + HEAD_CLEARANCE
+	MAX_HEALTH     = 100.0
+	PLAYER_MASS    = 2.0 // Scales how far impulses such as blasts push the player
+	MAX_PITCH      = math.Pi/2 - 0.1
+
+	CROUCH_EYE_HEIGHT = 1.0
+	CROUCH_HEIGHT     = CROUCH_EYE_HEIGHT + HEAD_CLEARANCE
 )
 
-// Player represents the player state
+// Player represents the player state. Position is the point between the
+// player's feet; the collision volume extends GetHeight() above it.
 type Player struct {
-	Position rl.Vector3
-	Yaw      float32
-	Pitch    float32
+	Position      rl.Vector3
+	PrevPosition  rl.Vector3
+	Velocity      rl.Vector3
+	PushVelocity  rl.Vector3 // Horizontal velocity of the last move before walls absorbed it, for shoving bodies
+	Yaw           float32
+	Pitch         float32
+	PrevYaw       float32
+	PrevPitch     float32
+	EyeHeight     float32 // Eases between standing and crouching eye heights
+	PrevEyeHeight float32
+	Grounded      bool
+	Crouching     bool
+	Movement      MovementParams
+
+	Sprinting         bool
+	Exhausted         bool // Out of stamina; sprinting is blocked until it recovers
+	Stamina           float32
+	StaminaRegenTimer float32
+
+	Health float32
+	Deaths int
 }
 
-// New creates a new player with default values
-func New() *Player {
+// New creates a new player at the given spawn point and facing
+func New(spawn rl.Vector3, yaw float32) *Player {
+	movement := DefaultMovementParams()
 	return &Player{
-		Position: rl.Vector3{X: 0, Y: 1, Z: 10},
-		Yaw:      0,
-		Pitch:    0,
+		Position:      spawn,
+		PrevPosition:  spawn,
+		Yaw:           yaw,
+		Pitch:         0,
+		PrevYaw:       yaw,
+		PrevPitch:     0,
+		EyeHeight:     EYE_HEIGHT,
+		PrevEyeHeight: EYE_HEIGHT,
+		Movement:      movement,
+		Stamina:       movement.MaxStamina,
+		Health:        MAX_HEALTH,
+	}
+}
+
+// TakeDamage reduces health and returns true if this damage killed the player
+func (p *Player) TakeDamage(damage float32) bool {
+	if !p.IsAlive() {
+		return false
 	}
+	p.Health -= damage
+	if p.Health <= 0 {
+		p.Health = 0
+		p.Deaths++
+		return true
+	}
+	return false
+}
+
+// ApplyImpulse changes the player's velocity by impulse/mass. An upward
+// push lifts the player off the ground so friction does not swallow it.
+func (p *Player) ApplyImpulse(impulse rl.Vector3) {
+	p.Velocity = rl.Vector3Add(p.Velocity, rl.Vector3Scale(impulse, 1/PLAYER_MASS))
+	if impulse.Y > 0 {
+		p.Grounded = false
+	}
+}
+
+// Rotate turns the view by the given angles in radians, keeping the pitch
+// short of straight up or down. Returns the yaw and pitch actually applied.
+func (p *Player) Rotate(yaw, pitch float32) (float32, float32) {
+	previous := p.Pitch
+	p.Yaw += yaw
+	p.Pitch += pitch
+	if p.Pitch > MAX_PITCH {
+		p.Pitch = MAX_PITCH
+	} else if p.Pitch < -MAX_PITCH {
+		p.Pitch = -MAX_PITCH
+	}
+	return yaw, p.Pitch - previous
+}
+
+// GetMovementFactor returns how fast the player is moving on the ground
+// plane as a fraction of full running speed, treating airborne as full speed
+func (p *Player) GetMovementFactor() float32 {
+	if !p.Grounded {
+		return 1
+	}
+	return min(p.GetHorizontalSpeed()/p.Movement.MaxSpeed, 1)
+}
+
+// IsAlive returns true if the player has health remaining
+func (p *Player) IsAlive() bool {
+	return p.Health > 0
+}
+
+// Respawn restores full health and moves the player to the spawn point
+func (p *Player) Respawn(spawn rl.Vector3, yaw float32) {
+	p.Position = spawn
+	p.PrevPosition = spawn // Avoid interpolating across the teleport
+	p.Velocity = rl.Vector3{}
+	p.PushVelocity = rl.Vector3{}
+	p.Yaw = yaw
+	p.Pitch = 0
+	p.PrevYaw = yaw // Snap the view rather than sweeping it
+	p.PrevPitch = 0
+	p.Grounded = false
+	p.Crouching = false
+	p.EyeHeight = EYE_HEIGHT
+	p.PrevEyeHeight = EYE_HEIGHT
+	p.Sprinting = false
+	p.Exhausted = false
+	p.Stamina = p.Movement.MaxStamina
+	p.StaminaRegenTimer = 0
+	p.Health = MAX_HEALTH
+}
+
+// SavePreviousState records the current position and view for render interpolation
+func (p *Player) SavePreviousState() {
+	p.PrevPosition = p.Position
+	p.PrevEyeHeight = p.EyeHeight
+	p.PrevYaw = p.Yaw
+	p.PrevPitch = p.Pitch
+}
+
+// GetInterpolatedPosition blends the previous and current tick positions
+func (p *Player) GetInterpolatedPosition(alpha float32) rl.Vector3 {
+	return rl.Vector3Lerp(p.PrevPosition, p.Position, alpha)
+}
+
+// GetInterpolatedEyePosition returns the eye position blended between ticks
+func (p *Player) GetInterpolatedEyePosition(alpha float32) rl.Vector3 {
+	pos := p.GetInterpolatedPosition(alpha)
+	pos.Y += p.PrevEyeHeight + (p.EyeHeight-p.PrevEyeHeight)*alpha
+	return pos
 }
 
 // GetEyePosition returns the camera position (player position + eye height)
 func (p *Player) GetEyePosition() rl.Vector3 {
 	return rl.Vector3{
 		X: p.Position.X,
-		Y: p.Position.Y + EYE_HEIGHT,
+		Y: p.Position.Y + p.EyeHeight,
 		Z: p.Position.Z,
 	}
 }
 
+// GetHeight returns the height of the collision volume for the current stance
+func (p *Player) GetHeight() float32 {
+	if p.Crouching {
+		return CROUCH_HEIGHT
+	}
+	return PLAYER_HEIGHT
+}
+
+// GetCollisionBox returns the player's collision volume at its current position
+func (p *Player) GetCollisionBox() rl.BoundingBox {
+	return GetCollisionBoxAt(p.Position, p.GetHeight())
+}
+
+// GetCollisionBoxAt returns the collision volume for a player of the given
+// height with feet at position
+func GetCollisionBoxAt(position rl.Vector3, height float32) rl.BoundingBox {
+	return rl.BoundingBox{
+		Min: rl.Vector3{X: position.X - PLAYER_RADIUS, Y: position.Y, Z: position.Z - PLAYER_RADIUS},
+		Max: rl.Vector3{X: position.X + PLAYER_RADIUS, Y: position.Y + height, Z: position.Z + PLAYER_RADIUS},
+	}
+}
+
+// MoveAndSlide moves the player by motion, sliding along any obstacles in the way
+func (p *Player) MoveAndSlide(motion rl.Vector3, world physics.Broadphase) physics.SlideResult {
+	result := physics.MoveAndSlide(p.GetCollisionBox(), motion, world)
+	p.Position = rl.Vector3Add(p.Position, result.Motion)
+	return result
+}
+
 // GetForwardVector returns the forward direction vector based on yaw and pitch
 func (p *Player) GetForwardVector() rl.Vector3 {
 	return rl.Vector3{
@@ -45,6 +204,29 @@ func (p *Player) GetForwardVector() rl.Vector3 {
 	}
 }
 
+// GetInterpolatedForwardVector returns the view direction with yaw and
+// pitch blended between the last two ticks, so looking around stays
+// smooth when frames outnumber ticks
+func (p *Player) GetInterpolatedForwardVector(alpha float32) rl.Vector3 {
+	yaw := float64(p.PrevYaw + (p.Yaw-p.PrevYaw)*alpha)
+	pitch := float64(p.PrevPitch + (p.Pitch-p.PrevPitch)*alpha)
+	return rl.Vector3{
+		X: float32(math.Sin(yaw) * math.Cos(pitch)),
+		Y: float32(math.Sin(pitch)),
+		Z: float32(math.Cos(yaw) * math.Cos(pitch)),
+	}
+}
+
+// GetFlatForwardVector returns the forward direction on the ground plane,
+// ignoring pitch so looking up or down does not change movement speed
+func (p *Player) GetFlatForwardVector() rl.Vector3 {
+	return rl.Vector3{
+		X: float32(math.Sin(float64(p.Yaw))),
+		Y: 0,
+		Z: float32(math.Cos(float64(p.Yaw))),
+	}
+}
+
 // GetRightVector returns the right direction vector based on yaw
 func (p *Player) GetRightVector() rl.Vector3 {
 	return rl.Vector3{
@@ -57,4 +239,4 @@ func (p *Player) GetRightVector() rl.Vector3 {
 // GetUpVector returns the up direction vector
 func (p *Player) GetUpVector() rl.Vector3 {
 	return rl.Vector3{X: 0, Y: 1, Z: 0}
-} 
\ No newline at end of file
+}
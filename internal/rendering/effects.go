@@ -0,0 +1,32 @@
+package rendering
+
+import (
+	"fps/internal/effects"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// renderEffects draws every live particle at its interpolated position,
+// with color and size taken from how far through its life it is
+func renderEffects(fx *effects.System, alpha float32) {
+	for i := range fx.Particles {
+		p := &fx.Particles[i]
+		if !p.IsActive {
+			continue
+		}
+		progress := p.GetProgress(alpha)
+		color := p.GetColor(progress)
+		size := p.GetSize(progress)
+		position := p.GetInterpolatedPosition(alpha)
+
+		switch p.Shape {
+		case effects.SHAPE_SPHERE:
+			rl.DrawSphereEx(position, size, 6, 6, color)
+		case effects.SHAPE_CUBE:
+			rl.DrawCube(position, size, size, size, color)
+		case effects.SHAPE_LINE:
+			rl.DrawLine3D(position, p.End, color)
+		case effects.SHAPE_RING:
+			rl.DrawCircle3D(position, size, rl.Vector3{X: 1}, 90, color)
+		}
+	}
+}
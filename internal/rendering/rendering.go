@@ -2,103 +2,188 @@ package rendering
 
 import (
 	"fmt"
+	"math"
 
-	rl "github.com/gen2brain/raylib-go/raylib"
-	"fps/internal/player"
+	"fps/internal/clock"
+	"fps/internal/effects"
 	"fps/internal/enemy"
+	"fps/internal/level"
 	"fps/internal/physics"
+	"fps/internal/player"
+	"fps/internal/weapon"
+	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
 // Constants for rendering
 const (
 	CROSSHAIR_SIZE      = 10
 	CROSSHAIR_THICKNESS = 2
-	TRACER_DURATION     = 0.5
+	CROSSHAIR_HIDE_AIM  = 0.5 // Aim blend past which the sights replace the crosshair
+	PROJECTILE_SIZE     = 0.08
+	STAMINA_BAR_WIDTH   = 200
+	STAMINA_BAR_HEIGHT  = 12
+	RELOAD_BAR_WIDTH    = 120
+	RELOAD_BAR_HEIGHT   = 6
+	GRID_SPACING        = 1.0 // Target distance between floor grid lines
 )
 
-// RenderWorld draws the 3D world elements
-func RenderWorld(p *player.Player, e *enemy.Enemy, cubes []rl.Vector3, colors []rl.Color, hitTimers []float32, tm *physics.TracerManager, camera rl.Camera3D) {
+// RenderWorld draws the 3D world elements, interpolating moving entities by alpha
+func RenderWorld(p *player.Player, enemies []*enemy.Enemy, lvl *level.Level, cubes, sizes []rl.Vector3, colors []rl.Color, hitTimers []float32, fx *effects.System, pm *physics.ProjectileManager, camera rl.Camera3D, alpha float32) {
 	rl.BeginMode3D(camera)
+	ground := lvl.Ground
 
 	// Draw ground plane with improved visual quality
-	groundColor := rl.NewColor(34, 139, 34, 255) // Forest green
-	rl.DrawPlane(rl.Vector3{X: 0, Y: 0, Z: 0}, rl.Vector2{X: 20, Y: 20}, groundColor)
-	
-	// Draw subtle grid lines on the ground for better depth perception
-	gridColor := rl.NewColor(0, 100, 0, 100) // Darker green, semi-transparent
-	for i := -10; i <= 10; i++ {
+	rl.DrawPlane(rl.Vector3{X: 0, Y: 0, Z: 0}, rl.Vector2{X: ground.Width, Y: ground.Depth}, ground.Color)
+
+	// Draw subtle grid lines on the ground for better depth perception.
+	// Lines are counted rather than stepped so both edges always get one.
+	halfWidth := ground.Width / 2
+	halfDepth := ground.Depth / 2
+	columns := gridLineCount(ground.Width)
+	for i := 0; i <= columns; i++ {
 		// Vertical lines
-		start := rl.Vector3{X: float32(i), Y: 0.01, Z: -10}
-		end := rl.Vector3{X: float32(i), Y: 0.01, Z: 10}
-		rl.DrawLine3D(start, end, gridColor)
-		
+		x := -halfWidth + ground.Width*float32(i)/float32(columns)
+		start := rl.Vector3{X: x, Y: 0.01, Z: -halfDepth}
+		end := rl.Vector3{X: x, Y: 0.01, Z: halfDepth}
+		rl.DrawLine3D(start, end, ground.GridColor)
+	}
+	rows := gridLineCount(ground.Depth)
+	for i := 0; i <= rows; i++ {
 		// Horizontal lines
-		start = rl.Vector3{X: -10, Y: 0.01, Z: float32(i)}
-		end = rl.Vector3{X: 10, Y: 0.01, Z: float32(i)}
-		rl.DrawLine3D(start, end, gridColor)
+		z := -halfDepth + ground.Depth*float32(i)/float32(rows)
+		start := rl.Vector3{X: -halfWidth, Y: 0.01, Z: z}
+		end := rl.Vector3{X: halfWidth, Y: 0.01, Z: z}
+		rl.DrawLine3D(start, end, ground.GridColor)
 	}
 
 	// Draw cubes with improved visual quality
 	for i, pos := range cubes {
+		size := sizes[i]
+
 		// Draw main cube with current color
-		rl.DrawCube(pos, 1, 1, 1, colors[i%len(colors)])
-		
+		rl.DrawCube(pos, size.X, size.Y, size.Z, colors[i%len(colors)])
+
 		// Draw wireframe with softer color for less harsh edges
 		wireframeColor := rl.NewColor(50, 50, 50, 255) // Dark gray instead of pure black
-		rl.DrawCubeWires(pos, 1, 1, 1, wireframeColor)
+		rl.DrawCubeWires(pos, size.X, size.Y, size.Z, wireframeColor)
+	}
+
+	// Draw kill volumes as translucent hazards
+	for _, kill := range lvl.KillVolumes {
+		renderVolume(kill.Volume, rl.NewColor(255, 40, 0, 60), rl.NewColor(255, 40, 0, 200))
+	}
+
+	// Draw every enemy
+	for _, e := range enemies {
+		renderEnemy(e, alpha)
+	}
+
+	// Draw player collision volume (semi-transparent box)
+	playerBox := player.GetCollisionBoxAt(p.GetInterpolatedPosition(alpha), p.GetHeight())
+	playerSize := rl.Vector3Subtract(playerBox.Max, playerBox.Min)
+	playerCenter := rl.Vector3Add(playerBox.Min, rl.Vector3Scale(playerSize, 0.5))
+	rl.DrawCubeV(playerCenter, playerSize, rl.NewColor(255, 0, 0, 100))
+
+	// Draw particles: tracers, impacts, blood, smoke and blasts
+	renderEffects(fx, alpha)
+
+	// Draw projectiles in flight
+	renderProjectiles(pm, alpha)
+
+	rl.EndMode3D()
+}
+
+// gridLineCount returns how many cells of roughly GRID_SPACING fit across
+// an extent, at least one
+func gridLineCount(extent float32) int {
+	return max(int(math.Round(float64(extent/GRID_SPACING))), 1)
+}
+
+// renderVolume draws a level volume as a filled box or an outlined prism
+func renderVolume(v level.Volume, fill, outline rl.Color) {
+	switch v.Type {
+	case level.VOLUME_BOX:
+		size := rl.Vector3Subtract(v.Max, v.Min)
+		center := rl.Vector3Add(v.Min, rl.Vector3Scale(size, 0.5))
+		rl.DrawCubeV(center, size, fill)
+		rl.DrawCubeWiresV(center, size, outline)
+	case level.VOLUME_CONVEX:
+		for i, a := range v.Points {
+			b := v.Points[(i+1)%len(v.Points)]
+			rl.DrawLine3D(rl.Vector3{X: a.X, Y: v.MinY, Z: a.Y}, rl.Vector3{X: b.X, Y: v.MinY, Z: b.Y}, outline)
+			rl.DrawLine3D(rl.Vector3{X: a.X, Y: v.MaxY, Z: a.Y}, rl.Vector3{X: b.X, Y: v.MaxY, Z: b.Y}, outline)
+			rl.DrawLine3D(rl.Vector3{X: a.X, Y: v.MinY, Z: a.Y}, rl.Vector3{X: a.X, Y: v.MaxY, Z: a.Y}, outline)
+		}
 	}
+}
 
-	// Draw enemy capsule
+// renderEnemy draws a single enemy at its interpolated position
+func renderEnemy(e *enemy.Enemy, alpha float32) {
+	// Draw enemy body
 	enemyColor := rl.NewColor(255, 0, 100, 255) // Bright pink/magenta for visibility
-	
+
 	// Change color based on hit status
 	if e.HitTimer > 0 {
 		enemyColor = rl.White // Flash white when hit
 	} else if e.Health < 50 {
 		enemyColor = rl.Red // Red when low health
 	}
-	
-	enemyHeight := float32(2.0) // Height of the capsule
-	enemyRadius := float32(0.5) // Radius of the capsule
-	
-	// Draw the enemy capsule (cylinder with rounded ends)
-	rl.DrawCylinder(e.Position, enemyRadius, enemyRadius, enemyHeight, 8, enemyColor)
-	
-	// Draw wireframe for the enemy
-	enemyWireframeColor := rl.NewColor(100, 0, 50, 255) // Darker pink for wireframe
-	rl.DrawCylinderWires(e.Position, enemyRadius, enemyRadius, enemyHeight, 8, enemyWireframeColor)
 
-	// Draw player representation (semi-transparent box)
-	rl.DrawCube(p.Position, 0.5, player.EYE_HEIGHT, 0.5, rl.NewColor(255, 0, 0, 100))
+	enemyPos := e.GetInterpolatedPosition(alpha)
+	enemyWireframeColor := rl.NewColor(100, 0, 50, 255) // Darker pink for wireframe
+	heightScale := float32(1)
+	radiusScale := float32(1)
 
-	// Draw tracers
-	renderTracers(tm)
+	// Death effect: sink into the ground, widen slightly and fade out
+	if !e.IsAlive() {
+		progress := e.GetDeathProgress()
+		heightScale = 1 - progress
+		radiusScale = 1 + progress*0.5
+		enemyColor = rl.ColorAlpha(rl.Maroon, 1-progress)
+		enemyWireframeColor = rl.ColorAlpha(enemyWireframeColor, 1-progress)
+	}
 
-	rl.EndMode3D()
+	// Draw each body part as its hitbox shape so what is seen is what is hit
+	for _, h := range e.Hitboxes {
+		h.Start.Y *= heightScale
+		h.End.Y *= heightScale
+		h.Radius *= radiusScale
+		renderHitbox(h.Translated(enemyPos), enemyColor, enemyWireframeColor)
+	}
 }
 
-// renderTracers draws all active tracer lines with fade effect
-func renderTracers(tm *physics.TracerManager) {
-	for _, tracer := range tm.GetActiveTracers() {
-		if tracer.IsActive {
-			// Calculate fade alpha based on remaining time
-			fadeRatio := tracer.TimeLeft / TRACER_DURATION
-			alpha := uint8(fadeRatio * 255)
-
-			// Create color with fade effect (bright yellow/orange tracer)
-			tracerColor := rl.NewColor(255, 255, 0, alpha) // Yellow with fade
+// renderHitbox draws a world-space hitbox shape filled and wireframed
+func renderHitbox(h enemy.Hitbox, color, wireColor rl.Color) {
+	switch h.Shape {
+	case enemy.SHAPE_SPHERE:
+		rl.DrawSphere(h.Start, h.Radius, color)
+		rl.DrawSphereWires(h.Start, h.Radius, 8, 8, wireColor)
+	case enemy.SHAPE_CAPSULE:
+		rl.DrawCapsule(h.Start, h.End, h.Radius, 8, 4, color)
+		rl.DrawCapsuleWires(h.Start, h.End, h.Radius, 8, 4, wireColor)
+	default:
+		size := rl.Vector3Subtract(h.End, h.Start)
+		center := rl.Vector3Add(h.Start, rl.Vector3Scale(size, 0.5))
+		rl.DrawCubeV(center, size, color)
+		rl.DrawCubeWiresV(center, size, wireColor)
+	}
+}
 
-			// Draw the tracer line
-			rl.DrawLine3D(tracer.Start, tracer.End, tracerColor)
-		}
+// renderProjectiles draws each projectile with a short streak along its path
+func renderProjectiles(pm *physics.ProjectileManager, alpha float32) {
+	for i := range pm.Projectiles {
+		p := &pm.Projectiles[i]
+		position := p.GetInterpolatedPosition(alpha)
+		rl.DrawSphere(position, PROJECTILE_SIZE, rl.Orange)
+		rl.DrawLine3D(p.PrevPosition, position, rl.Yellow)
 	}
 }
 
 // RenderUI draws all UI elements
-func RenderUI(p *player.Player, e *enemy.Enemy) {
+func RenderUI(p *player.Player, enemies []*enemy.Enemy, w *weapon.Weapon, c *clock.Clock) {
 	// Title and controls
 	rl.DrawText("FPS Camera with Perfect Mouse Control!", 10, 10, 20, rl.DarkGray)
-	rl.DrawText("WASD: Move | Mouse: Look | Left Click: Shoot | Tab: Toggle cursor | ESC: Exit", 10, 35, 16, rl.DarkGray)
+	rl.DrawText("WASD: Move | Shift: Sprint | Space: Jump | Ctrl: Crouch | Mouse: Look | Left Click: Shoot | Right Click: Aim | R: Reload | B: Fire mode | 1-4/Wheel: Switch weapon | P: Pause | Tab: Toggle cursor | ESC: Exit", 10, 35, 16, rl.DarkGray)
 
 	// Cursor status
 	if rl.IsCursorHidden() {
@@ -107,9 +192,31 @@ func RenderUI(p *player.Player, e *enemy.Enemy) {
 		rl.DrawText("Mouse free - press Tab to capture", 10, 55, 16, rl.Red)
 	}
 
-	// Enemy health display
-	enemyHealthText := fmt.Sprintf("Enemy Health: %.0f", e.Health)
-	rl.DrawText(enemyHealthText, 10, 75, 16, rl.White)
+	// Player health display
+	playerHealthText := fmt.Sprintf("Health: %.0f", p.Health)
+	rl.DrawText(playerHealthText, 10, 75, 16, rl.White)
+
+	// Stamina bar in the bottom left, red while exhausted
+	renderStaminaBar(p)
+
+	// Ammunition in the bottom right
+	renderAmmo(w)
+
+	// Enemy health display, one line per enemy
+	for i, e := range enemies {
+		enemyHealthText := fmt.Sprintf("Enemy #%d Health: %.0f", e.ID, e.Health)
+		if !e.IsAlive() {
+			enemyHealthText = fmt.Sprintf("Enemy #%d: DEAD", e.ID)
+		}
+		rl.DrawText(enemyHealthText, 10, int32(95+i*20), 16, rl.White)
+	}
+
+	// Pause banner in the center of the screen
+	if c.Paused {
+		pausedText := "PAUSED"
+		pausedWidth := rl.MeasureText(pausedText, 40)
+		rl.DrawText(pausedText, int32(rl.GetScreenWidth())/2-pausedWidth/2, int32(rl.GetScreenHeight())/3, 40, rl.White)
+	}
 
 	// FPS counter in top right
 	fps := rl.GetFPS()
@@ -119,15 +226,63 @@ func RenderUI(p *player.Player, e *enemy.Enemy) {
 	rl.DrawText(fpsText, screenWidth-fpsTextWidth-10, 10, 20, rl.White)
 }
 
-// RenderCrosshair draws the crosshair when in FPS mode
-func RenderCrosshair() {
-	if rl.IsCursorHidden() {
+// renderStaminaBar draws the player's stamina as a horizontal bar
+func renderStaminaBar(p *player.Player) {
+	barX := int32(10)
+	barY := int32(rl.GetScreenHeight()) - STAMINA_BAR_HEIGHT - 10
+	fill := p.Stamina / p.Movement.MaxStamina
+
+	barColor := rl.Gold
+	if p.Exhausted {
+		barColor = rl.Red
+	}
+
+	rl.DrawText("Stamina", barX, barY-18, 16, rl.White)
+	rl.DrawRectangle(barX, barY, STAMINA_BAR_WIDTH, STAMINA_BAR_HEIGHT, rl.ColorAlpha(rl.Black, 0.4))
+	rl.DrawRectangle(barX, barY, int32(float32(STAMINA_BAR_WIDTH)*fill), STAMINA_BAR_HEIGHT, barColor)
+	rl.DrawRectangleLines(barX, barY, STAMINA_BAR_WIDTH, STAMINA_BAR_HEIGHT, rl.White)
+}
+
+// renderAmmo draws the weapon name, fire mode and rounds left, with a
+// progress bar while reloading
+func renderAmmo(w *weapon.Weapon) {
+	screenWidth := int32(rl.GetScreenWidth())
+	screenHeight := int32(rl.GetScreenHeight())
+
+	ammoText := fmt.Sprintf("%d / %d", w.Ammo, w.Reserve)
+	ammoColor := rl.White
+	if w.Ammo == 0 {
+		ammoColor = rl.Red
+	}
+	ammoWidth := rl.MeasureText(ammoText, 30)
+	rl.DrawText(ammoText, screenWidth-ammoWidth-10, screenHeight-40, 30, ammoColor)
+
+	nameText := fmt.Sprintf("%s [%s]", w.Name, w.FireMode())
+	nameWidth := rl.MeasureText(nameText, 16)
+	rl.DrawText(nameText, screenWidth-nameWidth-10, screenHeight-60, 16, rl.White)
+
+	if w.IsReloading() {
+		barX := screenWidth - RELOAD_BAR_WIDTH - 10
+		barY := screenHeight - 70 - RELOAD_BAR_HEIGHT
+		rl.DrawText("Reloading", barX, barY-18, 16, rl.Yellow)
+		rl.DrawRectangle(barX, barY, RELOAD_BAR_WIDTH, RELOAD_BAR_HEIGHT, rl.ColorAlpha(rl.Black, 0.4))
+		rl.DrawRectangle(barX, barY, int32(float32(RELOAD_BAR_WIDTH)*w.ReloadProgress()), RELOAD_BAR_HEIGHT, rl.Yellow)
+	} else if w.Ammo == 0 && w.Reserve == 0 {
+		emptyWidth := rl.MeasureText("No ammo", 16)
+		rl.DrawText("No ammo", screenWidth-emptyWidth-10, screenHeight-80, 16, rl.Red)
+	}
+}
+
+// RenderCrosshair draws the crosshair when in FPS mode, hiding it once the
+// sights are mostly up
+func RenderCrosshair(aim float32) {
+	if rl.IsCursorHidden() && aim < CROSSHAIR_HIDE_AIM {
 		centerX := int32(rl.GetScreenWidth() / 2)
 		centerY := int32(rl.GetScreenHeight() / 2)
 
 		// Draw horizontal line
-	rl.DrawRectangle(centerX-CROSSHAIR_SIZE, centerY-CROSSHAIR_THICKNESS/2, CROSSHAIR_SIZE*2, CROSSHAIR_THICKNESS, rl.White)
+		rl.DrawRectangle(centerX-CROSSHAIR_SIZE, centerY-CROSSHAIR_THICKNESS/2, CROSSHAIR_SIZE*2, CROSSHAIR_THICKNESS, rl.White)
 		// Draw vertical line
-	rl.DrawRectangle(centerX-CROSSHAIR_THICKNESS/2, centerY-CROSSHAIR_SIZE, CROSSHAIR_THICKNESS, CROSSHAIR_SIZE*2, rl.White)
+		rl.DrawRectangle(centerX-CROSSHAIR_THICKNESS/2, centerY-CROSSHAIR_SIZE, CROSSHAIR_THICKNESS, CROSSHAIR_SIZE*2, rl.White)
 	}
-} 
\ No newline at end of file
+}
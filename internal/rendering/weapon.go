@@ -1,8 +1,9 @@
 package rendering
 
 import (
-	rl "github.com/gen2brain/raylib-go/raylib"
 	"fps/internal/player"
+	"fps/internal/weapon"
+	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
 // Constants for weapon rendering
@@ -11,17 +12,68 @@ const (
 	GUN_OFFSET_Y      = -0.3 // Gun offset down
 	GUN_OFFSET_Z      = 1.5  // Gun offset forward (away from camera)
 	GUN_BARREL_LENGTH = 0.8  // Length of gun barrel
+	GUN_LOWER_DROP    = 3.0  // How far the view model sinks when fully put away
+	GUN_SIGHT_DROP    = 0.3  // How far the view model drops to put its sights on the crosshair
+	GUN_SIGHT_PULL    = 1.0  // How far the view model comes toward the eye when aimed
 )
 
-// RenderWeaponViewport draws the weapon in a separate static viewport
-func RenderWeaponViewport(weaponCamera rl.Camera3D, weaponModel rl.Model) {
-	// Set up weapon viewport (bottom-right corner)
+// Assets holds presentation-only resources that require a graphics context
+type Assets struct {
+	WeaponCamera rl.Camera3D
+	WeaponModels []rl.Model // View model for each loadout slot
+}
+
+// LoadAssets loads models and cameras for a loadout; call only after the
+// window is created
+func LoadAssets(weapons []*weapon.Weapon) *Assets {
+	weaponModels := make([]rl.Model, len(weapons))
+	for i, w := range weapons {
+		weaponModels[i] = loadWeaponModel(w)
+	}
+
+	return &Assets{
+		WeaponCamera: rl.Camera3D{
+			Position:   rl.Vector3{X: 0, Y: 0, Z: 5},
+			Target:     rl.Vector3{X: 0, Y: 0, Z: 0},
+			Up:         rl.Vector3{X: 0, Y: 1, Z: 0},
+			Fovy:       60,
+			Projection: rl.CameraPerspective,
+		},
+		WeaponModels: weaponModels,
+	}
+}
+
+// loadWeaponModel loads a weapon's view model, falling back to a box of
+// the model's size so a missing file still shows something in hand
+func loadWeaponModel(w *weapon.Weapon) rl.Model {
+	if model := rl.LoadModel(w.ModelPath); model.MeshCount > 0 {
+		return model
+	}
+	size := w.ModelSize
+	return rl.LoadModelFromMesh(rl.GenMeshCube(size[0], size[1], size[2]))
+}
+
+// Unload releases GPU resources held by the assets
+func (a *Assets) Unload() {
+	for _, model := range a.WeaponModels {
+		rl.UnloadModel(model)
+	}
+}
+
+// RenderWeaponViewport draws the weapon in a separate static viewport.
+// lowered runs from 0 with the weapon ready to 1 with it put away, and aim
+// from 0 at the hip to 1 with the sights centered on the crosshair.
+func RenderWeaponViewport(weaponCamera rl.Camera3D, weaponModel rl.Model, lowered, aim float32) {
+	// Set up weapon viewport, sliding from the bottom-right corner at the
+	// hip to centered on the crosshair when aimed
 	screenWidth := rl.GetScreenWidth()
 	screenHeight := rl.GetScreenHeight()
 	weaponViewportWidth := screenWidth / 3
 	weaponViewportHeight := screenHeight / 3
-	weaponViewportX := screenWidth - weaponViewportWidth
-	weaponViewportY := screenHeight - weaponViewportHeight
+	hipX, hipY := float32(screenWidth-weaponViewportWidth), float32(screenHeight-weaponViewportHeight)
+	aimX, aimY := float32(screenWidth-weaponViewportWidth)/2, float32(screenHeight-weaponViewportHeight)/2
+	weaponViewportX := int(hipX + (aimX-hipX)*aim)
+	weaponViewportY := int(hipY + (aimY-hipY)*aim)
 
 	// Clear the weapon viewport area with a transparent background
 	rl.DrawRectangle(int32(weaponViewportX), int32(weaponViewportY), int32(weaponViewportWidth), int32(weaponViewportHeight), rl.ColorAlpha(rl.Black, 0.1))
@@ -32,8 +84,13 @@ func RenderWeaponViewport(weaponCamera rl.Camera3D, weaponModel rl.Model) {
 	// Begin weapon camera 3D mode
 	rl.BeginMode3D(weaponCamera)
 
-	// Position weapon at center of weapon viewport
-	weaponPos := rl.Vector3{X: 0.0, Y: 0.0, Z: 0.0}
+	// Position weapon at center of weapon viewport, sinking out of view while
+	// switching and dropping its sights onto the center line when aimed
+	weaponPos := rl.Vector3{
+		X: 0.0,
+		Y: -lowered*GUN_LOWER_DROP - aim*GUN_SIGHT_DROP,
+		Z: aim * GUN_SIGHT_PULL,
+	}
 	weaponScale := float32(0.5)
 
 	// Draw the weapon model
@@ -99,4 +156,4 @@ func calculateGunWorldPosition(p *player.Player, cameraPos rl.Vector3) rl.Vector
 	gunPos = rl.Vector3Add(gunPos, rl.Vector3Scale(forward, GUN_OFFSET_Z))
 
 	return gunPos
-} 
\ No newline at end of file
+}
@@ -0,0 +1,36 @@
+package weapon
+
+// UpdateAim raises the sights while aiming and lowers them otherwise,
+// taking AimTime for the full motion
+func (w *Weapon) UpdateAim(aiming bool, deltaTime float32) {
+	w.PrevAim = w.Aim
+	if w.AimTime <= 0 {
+		w.Aim = 0
+		if aiming {
+			w.Aim = 1
+		}
+		return
+	}
+	step := deltaTime / w.AimTime
+	if aiming {
+		w.Aim = min(w.Aim+step, 1)
+	} else {
+		w.Aim = max(w.Aim-step, 0)
+	}
+}
+
+// GetAimBlend returns how far the sights are raised, blended between the
+// last two ticks by alpha and eased so the motion starts and stops gently
+func (w *Weapon) GetAimBlend(alpha float32) float32 {
+	aim := w.PrevAim + (w.Aim-w.PrevAim)*alpha
+	return aim * aim * (3 - 2*aim)
+}
+
+// GetFov returns the camera field of view for a hip-fire field of view,
+// narrowed toward AimFov as the sights come up
+func (w *Weapon) GetFov(hipFov, alpha float32) float32 {
+	if w.AimFov <= 0 {
+		return hipFov
+	}
+	return hipFov + (w.AimFov-hipFov)*w.GetAimBlend(alpha)
+}
@@ -0,0 +1,162 @@
+package weapon
+
+import (
+	"fps/internal/clock"
+)
+
+// SwitchPhase is where the loadout is in swapping weapons
+type SwitchPhase int
+
+const (
+	SWITCH_READY    SwitchPhase = iota // Active weapon is up and usable
+	SWITCH_LOWERING                    // Active weapon is being put away
+	SWITCH_RAISING                     // Active weapon is being brought up
+)
+
+// Loadout is the set of weapons the player carries, one per slot, and the
+// state of switching between them
+type Loadout struct {
+	Slots      []*Weapon
+	Active     int // Slot of the weapon in hand
+	Pending    int // Slot to bring up once the active weapon is lowered
+	Phase      SwitchPhase
+	PhaseTimer float32 // Seconds left in the current phase
+}
+
+// NewLoadout creates a loadout holding weapons in slot order with the first one ready
+func NewLoadout(weapons ...*Weapon) *Loadout {
+	return &Loadout{Slots: weapons}
+}
+
+// DefaultLoadout creates a loadout with every preset weapon, in PRESETS order
+func DefaultLoadout() *Loadout {
+	weapons := make([]*Weapon, len(PRESETS))
+	for i, name := range PRESETS {
+		weapons[i], _ = ByName(name)
+	}
+	return NewLoadout(weapons...)
+}
+
+// SlotByName returns the default loadout slot holding a preset weapon
+func SlotByName(name string) (int, bool) {
+	for i, preset := range PRESETS {
+		if preset == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Current returns the weapon in hand
+func (l *Loadout) Current() *Weapon {
+	return l.Slots[l.Active]
+}
+
+// Equip puts the weapon in a slot in hand immediately, skipping the switch animation
+func (l *Loadout) Equip(slot int) bool {
+	if slot < 0 || slot >= len(l.Slots) {
+		return false
+	}
+	l.Current().CancelReload()
+	l.Current().ReleaseTrigger()
+	l.Active = slot
+	l.Pending = slot
+	l.Phase = SWITCH_READY
+	l.PhaseTimer = 0
+	return true
+}
+
+// Select starts switching to the weapon in a slot. The active weapon is
+// lowered first, abandoning any reload, then the new one is raised.
+// Selecting the weapon already in hand cancels a switch away from it.
+func (l *Loadout) Select(slot int) bool {
+	if slot < 0 || slot >= len(l.Slots) || slot == l.Pending {
+		return false
+	}
+	l.Pending = slot
+	w := l.Current()
+
+	switch l.Phase {
+	case SWITCH_READY:
+		w.CancelReload()
+		w.ReleaseTrigger()
+		l.startLowering(1)
+	case SWITCH_RAISING:
+		// Put the weapon away from however far up it got
+		l.startLowering(1 - l.Lowered())
+	case SWITCH_LOWERING:
+		if slot == l.Active {
+			// Bring the weapon back up from however far down it got
+			l.PhaseTimer = w.RaiseTime * l.Lowered()
+			l.Phase = SWITCH_RAISING
+		}
+	}
+	return true
+}
+
+// Cycle selects the next (step > 0) or previous (step < 0) slot, wrapping around
+func (l *Loadout) Cycle(step int) bool {
+	n := len(l.Slots)
+	return l.Select(((l.Pending+step)%n + n) % n)
+}
+
+// Update advances the switch in progress and the weapon in hand. Weapons
+// that are put away do not reload or cool down.
+func (l *Loadout) Update(c *clock.Clock) {
+	l.Current().Update(c)
+	if l.Phase == SWITCH_READY {
+		return
+	}
+
+	l.PhaseTimer -= c.Delta
+	if l.PhaseTimer > 0 {
+		return
+	}
+	switch l.Phase {
+	case SWITCH_LOWERING:
+		l.Active = l.Pending
+		l.Phase = SWITCH_RAISING
+		l.PhaseTimer += l.Current().RaiseTime
+		if l.PhaseTimer <= 0 {
+			l.finishRaising()
+		}
+	case SWITCH_RAISING:
+		l.finishRaising()
+	}
+}
+
+// IsReady reports whether the weapon in hand is fully up and can be used
+func (l *Loadout) IsReady() bool {
+	return l.Phase == SWITCH_READY
+}
+
+// Lowered returns how far the weapon in hand is put away, from 0 (ready) to 1 (holstered)
+func (l *Loadout) Lowered() float32 {
+	w := l.Current()
+	switch l.Phase {
+	case SWITCH_LOWERING:
+		if w.LowerTime <= 0 {
+			return 1
+		}
+		return 1 - l.PhaseTimer/w.LowerTime
+	case SWITCH_RAISING:
+		if w.RaiseTime <= 0 {
+			return 0
+		}
+		return l.PhaseTimer / w.RaiseTime
+	}
+	return 0
+}
+
+// startLowering begins putting the active weapon away, covering only the
+// given fraction of the full motion
+func (l *Loadout) startLowering(fraction float32) {
+	l.Phase = SWITCH_LOWERING
+	l.PhaseTimer = l.Current().LowerTime * fraction
+}
+
+// finishRaising makes the active weapon usable
+func (l *Loadout) finishRaising() {
+	l.Phase = SWITCH_READY
+	l.PhaseTimer = 0
+}
@@ -0,0 +1,87 @@
+package weapon
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Spread returns the current cone half-angle in radians. movement is how
+// fast the shooter is moving as a fraction of full running speed. Aiming
+// down sights tightens the cone toward AimSpreadFactor.
+func (w *Weapon) Spread(movement float32) float32 {
+	spread := w.SpreadBase + w.Bloom + w.SpreadMove*movement
+	if w.SpreadMax > 0 && spread > w.SpreadMax {
+		spread = w.SpreadMax
+	}
+	if w.AimSpreadFactor > 0 {
+		spread *= 1 + (w.AimSpreadFactor-1)*w.GetAimBlend(1)
+	}
+	return spread
+}
+
+// Deviation picks where in a cone of the given half-angle the next round
+// goes, as yaw and pitch offsets in radians spread evenly over the cone
+func (w *Weapon) Deviation(spread float32) (float32, float32) {
+	rng := w.random()
+	angle := rng.Float64() * 2 * math.Pi
+	radius := float64(spread) * math.Sqrt(rng.Float64())
+	return float32(radius * math.Cos(angle)), float32(radius * math.Sin(angle))
+}
+
+// Kick builds up spread from a round just fired and returns the yaw and
+// pitch the view is thrown by. Pass the rotation the view actually took to
+// AddRecoil so RecoverAim can settle it back afterwards.
+func (w *Weapon) Kick() (float32, float32) {
+	// Bloom past the cap would only delay recovery, so it stops growing there
+	w.Bloom += w.SpreadPerShot
+	if w.SpreadMax > 0 && w.Bloom > w.SpreadMax-w.SpreadBase {
+		w.Bloom = max(w.SpreadMax-w.SpreadBase, 0)
+	}
+	yaw := w.RecoilYaw * (w.random().Float32()*2 - 1)
+	return yaw, w.RecoilPitch
+}
+
+// AddRecoil remembers a kick applied to the view for RecoverAim to undo.
+// Only the part the view really turned by is owed, so a kick clamped at
+// the pitch limit is not paid back past where the player was aiming.
+func (w *Weapon) AddRecoil(yaw, pitch float32) {
+	w.RecoilDebt[0] += pitch
+	w.RecoilDebt[1] += yaw
+}
+
+// RecoverAim returns the yaw and pitch that settle the view back toward
+// where it was aimed before recoil over a step of deltaTime seconds. The
+// view only settles once the weapon stops firing, so sustained fire climbs.
+func (w *Weapon) RecoverAim(deltaTime float32) (float32, float32) {
+	pitch, yaw := w.RecoilDebt[0], w.RecoilDebt[1]
+	debt := float32(math.Hypot(float64(pitch), float64(yaw)))
+	if debt == 0 || w.Cooldown > 0 {
+		return 0, 0
+	}
+	step := w.RecoilRecovery * deltaTime
+	if step >= debt {
+		w.RecoilDebt = [2]float32{}
+		return -yaw, -pitch
+	}
+	scale := step / debt
+	w.RecoilDebt[0] -= pitch * scale
+	w.RecoilDebt[1] -= yaw * scale
+	return -yaw * scale, -pitch * scale
+}
+
+// recoverSpread shrinks the spread built up by sustained fire
+func (w *Weapon) recoverSpread(deltaTime float32) {
+	w.Bloom -= w.SpreadRecovery * deltaTime
+	if w.Bloom < 0 {
+		w.Bloom = 0
+	}
+}
+
+// random returns the weapon's pattern generator, seeding it on first use
+// for weapons built without a preset
+func (w *Weapon) random() *rand.Rand {
+	if w.rng == nil {
+		w.rng = rand.New(rand.NewSource(patternSeed(w.Name)))
+	}
+	return w.rng
+}
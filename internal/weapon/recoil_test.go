@@ -0,0 +1,55 @@
+package weapon
+
+import (
+	"reflect"
+	"testing"
+
+	"fps/internal/clock"
+)
+
+// TestBloomCappedAtMaxSpread empties a magazine and checks the built-up
+// spread stops at SpreadMax and recovers as soon as firing stops
+func TestBloomCappedAtMaxSpread(t *testing.T) {
+	w := Rifle()
+	for i := 0; i < w.MagazineSize; i++ {
+		w.Kick()
+	}
+	if limit := w.SpreadMax - w.SpreadBase; w.Bloom > limit {
+		t.Fatalf("bloom = %v, want at most %v", w.Bloom, limit)
+	}
+	if spread := w.Spread(0); spread != w.SpreadMax {
+		t.Fatalf("spread = %v, want SpreadMax %v", spread, w.SpreadMax)
+	}
+
+	c := clock.New()
+	c.Advance(0.1)
+	w.Update(c)
+	if spread := w.Spread(0); spread >= w.SpreadMax {
+		t.Fatalf("spread = %v after firing stopped, want below SpreadMax %v", spread, w.SpreadMax)
+	}
+}
+
+// deviations draws the first few spread offsets a weapon produces
+func deviations(w *Weapon) [][2]float32 {
+	var offsets [][2]float32
+	for i := 0; i < 5; i++ {
+		yaw, pitch := w.Deviation(w.SpreadMax)
+		offsets = append(offsets, [2]float32{yaw, pitch})
+	}
+	return offsets
+}
+
+// TestPatternsSeededPerWeapon checks each preset draws its own spread
+// pattern while the same preset always draws the same one
+func TestPatternsSeededPerWeapon(t *testing.T) {
+	first := deviations(Rifle())
+	if again := deviations(Rifle()); !reflect.DeepEqual(first, again) {
+		t.Fatalf("two rifles drew different patterns: %v and %v", first, again)
+	}
+	for _, name := range PRESETS[1:] {
+		w, _ := ByName(name)
+		if got := deviations(w); reflect.DeepEqual(got, deviations(Rifle())) {
+			t.Fatalf("%s draws the same pattern as the rifle", name)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package weapon
+
+import (
+	"fps/internal/clock"
+)
+
+// Update counts down the fire cooldown and any reload in progress,
+// refilling the magazine from the reserve when a reload completes, and
+// lets spread from sustained fire recover
+func (w *Weapon) Update(c *clock.Clock) {
+	deltaTime := c.Delta
+	// The cooldown may dip below zero by up to one tick so that leftover
+	// time carries into the next shot; it never accumulates further
+	if w.Cooldown > 0 {
+		w.Cooldown -= deltaTime
+	} else {
+		w.recoverSpread(deltaTime)
+	}
+	if w.ReloadTimer > 0 {
+		w.ReloadTimer -= deltaTime
+		if w.ReloadTimer <= 0 {
+			w.ReloadTimer = 0
+			w.finishReload()
+		}
+	}
+}
+
+// Trigger applies the trigger state for this tick and returns how many
+// rounds the weapon releases. Semi-auto fires once per pull, burst fires
+// BurstCount rounds per pull even if the trigger is let go, and automatic
+// fires for as long as the trigger is held. Rounds are paced by
+// FireInterval on the simulation clock, so the rate does not depend on
+// frame rate and an interval shorter than a tick releases several rounds.
+func (w *Weapon) Trigger(held bool) int {
+	pulled := held && !w.TriggerHeld
+	w.TriggerHeld = held
+	if pulled && w.FireMode() == FIRE_BURST && w.BurstLeft == 0 {
+		w.BurstLeft = w.BurstCount
+	}
+
+	rounds := 0
+	for w.wantsRound(pulled && rounds == 0) && w.TryFire() {
+		rounds++
+		if w.BurstLeft > 0 {
+			w.BurstLeft--
+		}
+		if w.FireInterval <= 0 {
+			break // No pacing, so one round per tick
+		}
+	}
+
+	// A burst ends early when the magazine runs dry
+	if w.Ammo == 0 {
+		w.BurstLeft = 0
+	}
+	return rounds
+}
+
+// wantsRound reports whether the fire mode calls for another round;
+// pulled is true only for the first round of a fresh trigger pull
+func (w *Weapon) wantsRound(pulled bool) bool {
+	switch w.FireMode() {
+	case FIRE_AUTO:
+		return w.TriggerHeld
+	case FIRE_BURST:
+		return w.BurstLeft > 0
+	}
+	return pulled
+}
+
+// CycleFireMode switches to the weapon's next fire mode, ending any burst
+func (w *Weapon) CycleFireMode() {
+	if len(w.FireModes) == 0 {
+		return
+	}
+	w.Mode = (w.Mode + 1) % len(w.FireModes)
+	w.BurstLeft = 0
+}
+
+// ReleaseTrigger ends any burst in progress and forgets unrecovered recoil,
+// as when the weapon is put away
+func (w *Weapon) ReleaseTrigger() {
+	w.BurstLeft = 0
+	w.RecoilDebt = [2]float32{}
+}
+
+// CanFire reports whether a round is chambered and the weapon is ready
+func (w *Weapon) CanFire() bool {
+	return w.Ammo > 0 && w.Cooldown <= 0 && !w.IsReloading()
+}
+
+// TryFire spends one round if the weapon is ready and reports whether it
+// fired. Pulling the trigger on an empty magazine starts a reload.
+func (w *Weapon) TryFire() bool {
+	if w.Ammo <= 0 {
+		w.StartReload()
+		return false
+	}
+	if !w.CanFire() {
+		return false
+	}
+	w.Ammo--
+	w.Cooldown += w.FireInterval
+	return true
+}
+
+// StartReload begins swapping magazines and reports whether it started.
+// Nothing happens when the magazine is full, the reserve is empty or a
+// reload is already underway.
+func (w *Weapon) StartReload() bool {
+	if w.IsReloading() || w.Ammo >= w.MagazineSize || w.Reserve <= 0 {
+		return false
+	}
+	w.ReloadTimer = w.ReloadTime
+	return true
+}
+
+// CancelReload abandons a reload in progress, keeping the current magazine
+func (w *Weapon) CancelReload() {
+	w.ReloadTimer = 0
+}
+
+// IsReloading reports whether a reload is in progress
+func (w *Weapon) IsReloading() bool {
+	return w.ReloadTimer > 0
+}
+
+// ReloadProgress returns how far through the current reload the weapon is, from 0 to 1
+func (w *Weapon) ReloadProgress() float32 {
+	if !w.IsReloading() || w.ReloadTime <= 0 {
+		return 0
+	}
+	return 1 - w.ReloadTimer/w.ReloadTime
+}
+
+// FireMode returns the selected fire mode
+func (w *Weapon) FireMode() FireMode {
+	if len(w.FireModes) == 0 {
+		return FIRE_SEMI
+	}
+	return w.FireModes[w.Mode%len(w.FireModes)]
+}
+
+// finishReload moves rounds from the reserve into the magazine
+func (w *Weapon) finishReload() {
+	rounds := w.MagazineSize - w.Ammo
+	if rounds > w.Reserve {
+		rounds = w.Reserve
+	}
+	w.Ammo += rounds
+	w.Reserve -= rounds
+}
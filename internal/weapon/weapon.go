@@ -0,0 +1,276 @@
+package weapon
+
+import (
+	"hash/fnv"
+	"math/rand"
+)
+
+// Constants for weapons
+const (
+	PATTERN_SEED = 1 // Fixed so spread and recoil replay identically; each weapon mixes in its name
+)
+
+// Ballistics selects how a weapon's rounds travel
+type Ballistics int
+
+const (
+	BALLISTICS_HITSCAN    Ballistics = iota // Instant ray out to Range
+	BALLISTICS_PROJECTILE                   // Simulated round with travel time and drop
+)
+
+// FireMode selects how the trigger releases rounds
+type FireMode int
+
+const (
+	FIRE_SEMI  FireMode = iota // One round per trigger pull
+	FIRE_BURST                 // A short fixed burst per trigger pull
+	FIRE_AUTO                  // Rounds keep coming while the trigger is held
+)
+
+// String returns the mode's display name
+func (m FireMode) String() string {
+	switch m {
+	case FIRE_BURST:
+		return "Burst"
+	case FIRE_AUTO:
+		return "Auto"
+	}
+	return "Semi"
+}
+
+// Weapon describes how a gun's rounds behave and tracks its ammunition
+type Weapon struct {
+	Name        string
+	ModelPath   string     // View model file
+	ModelSize   [3]float32 // Width, height and length of the view model, drawn as a box if it fails to load
+	RaiseTime   float32    // Seconds to bring the weapon up after switching to it
+	LowerTime   float32    // Seconds to put the weapon away when switching from it
+	Damage      float32    // Damage per round before hit region multipliers
+	Range       float32    // Maximum distance a round travels
+	Penetration float32    // Resistance budget spent passing through materials; zero stops at the first surface
+	Impulse     float32    // Momentum a round delivers to rigid bodies
+
+	Ballistics     Ballistics
+	MuzzleVelocity float32 // Projectile launch speed in units per second
+	Gravity        float32 // Downward acceleration on projectiles
+	BlastRadius    float32 // Projectiles explode on impact when positive, dealing Damage with falloff
+
+	FireInterval float32 // Minimum seconds between rounds
+	MagazineSize int
+	ReserveSize  int     // Spare rounds carried when fully stocked
+	ReloadTime   float32 // Seconds to swap magazines
+	FireModes    []FireMode
+	BurstCount   int // Rounds per trigger pull in FIRE_BURST
+
+	SpreadBase     float32 // Cone half-angle in radians for a first shot while standing still
+	SpreadPerShot  float32 // Spread added by each round fired
+	SpreadMove     float32 // Spread added at full running speed
+	SpreadMax      float32
+	SpreadRecovery float32 // Radians per second of shot spread lost once firing stops
+
+	RecoilPitch    float32 // Upward view kick per round in radians
+	RecoilYaw      float32 // Largest sideways view kick per round in radians
+	RecoilRecovery float32 // Radians per second the view settles back once firing stops
+
+	AimFov          float32 // Camera field of view in degrees when aiming down sights
+	AimTime         float32 // Seconds to raise the sights fully
+	AimSpreadFactor float32 // Spread multiplier when fully aimed
+
+	// Runtime state
+	Ammo        int        // Rounds in the magazine
+	Reserve     int        // Spare rounds outside the magazine
+	Mode        int        // Index into FireModes
+	TriggerHeld bool       // Trigger state on the last tick, for detecting pulls
+	BurstLeft   int        // Rounds still to fire in the current burst
+	Bloom       float32    // Spread built up by sustained fire
+	RecoilDebt  [2]float32 // Pitch and yaw kick not yet recovered
+	Aim         float32    // How far the sights are raised, from 0 (hip) to 1 (aimed)
+	PrevAim     float32
+	rng         *rand.Rand
+	Cooldown    float32 // Seconds until the next round can fire
+	ReloadTimer float32 // Seconds left in the current reload, zero when not reloading
+}
+
+// Rifle returns the default assault rifle, able to punch through thin cover
+func Rifle() *Weapon {
+	return stocked(&Weapon{
+		Name:         "AK-47",
+		ModelPath:    "assets/ak47.glb",
+		ModelSize:    [3]float32{1, 1, 3},
+		RaiseTime:    0.4,
+		LowerTime:    0.3,
+		Damage:       25,
+		Range:        100,
+		Penetration:  20,
+		Impulse:      1,
+		Ballistics:   BALLISTICS_HITSCAN,
+		FireInterval: 0.1,
+		MagazineSize: 30,
+		ReserveSize:  90,
+		ReloadTime:   2.5,
+		FireModes:    []FireMode{FIRE_AUTO, FIRE_BURST, FIRE_SEMI},
+		BurstCount:   3,
+
+		SpreadBase:     0.004,
+		SpreadPerShot:  0.006,
+		SpreadMove:     0.04,
+		SpreadMax:      0.08,
+		SpreadRecovery: 0.15,
+		RecoilPitch:    0.008,
+		RecoilYaw:      0.005,
+		RecoilRecovery: 0.3,
+
+		AimFov:          40,
+		AimTime:         0.2,
+		AimSpreadFactor: 0.3,
+	})
+}
+
+// Marksman returns a long-range rifle whose rounds travel and drop
+func Marksman() *Weapon {
+	return stocked(&Weapon{
+		Name:           "Marksman Rifle",
+		ModelPath:      "assets/marksman.obj",
+		ModelSize:      [3]float32{0.6, 0.8, 4.5},
+		RaiseTime:      0.6,
+		LowerTime:      0.4,
+		Damage:         60,
+		Range:          400,
+		Impulse:        6,
+		Ballistics:     BALLISTICS_PROJECTILE,
+		MuzzleVelocity: 150,
+		Gravity:        9.8,
+		FireInterval:   0.5,
+		MagazineSize:   10,
+		ReserveSize:    30,
+		ReloadTime:     3,
+		FireModes:      []FireMode{FIRE_SEMI},
+
+		SpreadBase:     0.001,
+		SpreadPerShot:  0.02,
+		SpreadMove:     0.06,
+		SpreadMax:      0.05,
+		SpreadRecovery: 0.08,
+		RecoilPitch:    0.04,
+		RecoilYaw:      0.01,
+		RecoilRecovery: 0.2,
+
+		AimFov:          20,
+		AimTime:         0.3,
+		AimSpreadFactor: 0.1,
+	})
+}
+
+// RocketLauncher returns a slow, heavy projectile launcher
+func RocketLauncher() *Weapon {
+	return stocked(&Weapon{
+		Name:           "Rocket Launcher",
+		ModelPath:      "assets/rocket_launcher.obj",
+		ModelSize:      [3]float32{1.2, 1.2, 4},
+		RaiseTime:      0.9,
+		LowerTime:      0.6,
+		Damage:         100,
+		Range:          150,
+		Impulse:        20,
+		Ballistics:     BALLISTICS_PROJECTILE,
+		MuzzleVelocity: 25,
+		BlastRadius:    4,
+		FireInterval:   1,
+		MagazineSize:   1,
+		ReserveSize:    5,
+		ReloadTime:     3.5,
+		FireModes:      []FireMode{FIRE_SEMI},
+
+		SpreadBase:     0.01,
+		SpreadMove:     0.02,
+		SpreadMax:      0.03,
+		SpreadRecovery: 0.1,
+		RecoilPitch:    0.05,
+		RecoilYaw:      0.01,
+		RecoilRecovery: 0.15,
+
+		AimFov:          45,
+		AimTime:         0.3,
+		AimSpreadFactor: 0.5,
+	})
+}
+
+// GrenadeLauncher returns a lobbed launcher with a steep arc
+func GrenadeLauncher() *Weapon {
+	return stocked(&Weapon{
+		Name:           "Grenade Launcher",
+		ModelPath:      "assets/grenade_launcher.obj",
+		ModelSize:      [3]float32{1.4, 1.2, 2.2},
+		RaiseTime:      0.6,
+		LowerTime:      0.4,
+		Damage:         80,
+		Range:          60,
+		Impulse:        15,
+		Ballistics:     BALLISTICS_PROJECTILE,
+		MuzzleVelocity: 18,
+		Gravity:        15,
+		BlastRadius:    3,
+		FireInterval:   0.8,
+		MagazineSize:   6,
+		ReserveSize:    18,
+		ReloadTime:     4,
+		FireModes:      []FireMode{FIRE_SEMI},
+
+		SpreadBase:     0.01,
+		SpreadPerShot:  0.01,
+		SpreadMove:     0.02,
+		SpreadMax:      0.04,
+		SpreadRecovery: 0.1,
+		RecoilPitch:    0.03,
+		RecoilYaw:      0.01,
+		RecoilRecovery: 0.15,
+
+		AimFov:          50,
+		AimTime:         0.25,
+		AimSpreadFactor: 0.6,
+	})
+}
+
+// stocked fills a new weapon's magazine and reserve and seeds its spread
+// and recoil pattern
+func stocked(w *Weapon) *Weapon {
+	w.Ammo = w.MagazineSize
+	w.Reserve = w.ReserveSize
+	w.rng = rand.New(rand.NewSource(patternSeed(w.Name)))
+	return w
+}
+
+// patternSeed returns the spread and recoil seed for a weapon. Mixing in
+// the name gives each weapon its own pattern that still replays identically.
+func patternSeed(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return PATTERN_SEED ^ int64(h.Sum64())
+}
+
+// PRESETS lists the weapon preset names in loadout slot order
+var PRESETS = []string{"rifle", "marksman", "rocket", "grenade"}
+
+// ByName returns a new weapon from its preset name: rifle, marksman, rocket or grenade
+func ByName(name string) (*Weapon, bool) {
+	switch name {
+	case "rifle":
+		return Rifle(), true
+	case "marksman":
+		return Marksman(), true
+	case "rocket":
+		return RocketLauncher(), true
+	case "grenade":
+		return GrenadeLauncher(), true
+	}
+	return nil, false
+}
+
+// PenetrationDamage scales damage by how much penetration power is left
+// after passing through cover
+func (w *Weapon) PenetrationDamage(power float32) float32 {
+	if w.Penetration <= 0 {
+		return w.Damage
+	}
+	return w.Damage * power / w.Penetration
+}
@@ -0,0 +1,26 @@
+package weapon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestPresetsHaveOwnModels checks every preset weapon ships a view model
+// file of its own
+func TestPresetsHaveOwnModels(t *testing.T) {
+	seen := map[string]string{}
+	for _, name := range PRESETS {
+		w, _ := ByName(name)
+		if w.ModelPath == "" {
+			t.Fatalf("%s has no view model", name)
+		}
+		if other, ok := seen[w.ModelPath]; ok {
+			t.Fatalf("%s shares its view model with %s", name, other)
+		}
+		seen[w.ModelPath] = name
+		if _, err := os.Stat(filepath.Join("..", "..", w.ModelPath)); err != nil {
+			t.Fatalf("%s view model: %v", name, err)
+		}
+	}
+}